@@ -0,0 +1,71 @@
+/* Copyright 2012 Marc-Antoine Ruel. Licensed under the Apache License, Version
+2.0 (the "License"); you may not use this file except in compliance with the
+License.  You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0. Unless required by applicable law or
+agreed to in writing, software distributed under the License is distributed on
+an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+or implied. See the License for the specific language governing permissions and
+limitations under the License. */
+
+package rs
+
+import (
+	"fmt"
+)
+
+// Fields used by Aztec codes, as defined in ISO/IEC 24778. The generator
+// polynomial roots of all of them are α^1 to α^c, so the encoders and decoders
+// must be created with NewAztecEncoder and NewAztecDecoder.
+var (
+	// AztecParamField is used by the mode message, which has 2 data words
+	// and 5 ECC words in compact symbols and 4 data words and 6 ECC words in
+	// full range symbols.
+	//
+	// x^4 + x + 1
+	AztecParamField = NewWideField(0x13, 2)
+	// AztecData6Field is used by the data of symbols of 1 and 2 layers.
+	//
+	// x^6 + x + 1
+	AztecData6Field = NewWideField(0x43, 2)
+	// AztecData8Field is used by the data of symbols of 3 to 8 layers. It is
+	// the same field as DataMatrixField256.
+	//
+	// x^8 + x^5 + x^3 + x^2 + 1
+	AztecData8Field = NewWideField(0x12D, 2)
+	// AztecData10Field is used by the data of symbols of 9 to 22 layers.
+	//
+	// x^10 + x^3 + 1
+	AztecData10Field = NewWideField(0x409, 2)
+	// AztecData12Field is used by the data of symbols of 23 to 32 layers.
+	//
+	// x^12 + x^6 + x^5 + x^3 + 1
+	AztecData12Field = NewWideField(0x1069, 2)
+)
+
+// AztecDataField returns the field used by the data of an Aztec symbol of
+// layers layers, between 1 and 32 inclusively.
+func AztecDataField(layers int) *WideField {
+	switch {
+	case layers < 1 || layers > 32:
+		panic(fmt.Sprintf("rs: invalid Aztec layers: %d", layers))
+	case layers <= 2:
+		return AztecData6Field
+	case layers <= 8:
+		return AztecData8Field
+	case layers <= 22:
+		return AztecData10Field
+	default:
+		return AztecData12Field
+	}
+}
+
+// NewAztecEncoder returns an encoder of c ECC words in one of the Aztec
+// fields.
+func NewAztecEncoder(f *WideField, c int) WideEncoder {
+	return NewWideEncoder(f, c, FirstRoot(1))
+}
+
+// NewAztecDecoder returns a decoder in one of the Aztec fields.
+func NewAztecDecoder(f *WideField) WideDecoder {
+	return NewWideDecoder(f, FirstRoot(1))
+}
@@ -0,0 +1,100 @@
+/* Copyright 2012 Marc-Antoine Ruel. Licensed under the Apache License, Version
+2.0 (the "License"); you may not use this file except in compliance with the
+License.  You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0. Unless required by applicable law or
+agreed to in writing, software distributed under the License is distributed on
+an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+or implied. See the License for the specific language governing permissions and
+limitations under the License. */
+
+package rs
+
+import (
+	"fmt"
+)
+
+// BlockCodec protects buffers of arbitrary size by splitting them into
+// shortened RS(255,k) blocks, each one having its own ECC.
+//
+// The data is cut in consecutive blocks of k = 255-c bytes, the last one being
+// shorter as needed. The ECC of each block is stored consecutively in the ecc
+// buffer.
+type BlockCodec struct {
+	c int
+	e Encoder
+	d Decoder
+}
+
+// NewBlockCodec returns a BlockCodec where each block of up to 255 bytes
+// contains c ECC bytes. The ECC ratio is thus c/255 and up to c/2 errors per
+// block can be corrected.
+//
+// c must be between 1 and 254 inclusively.
+func NewBlockCodec(f *Field, c int) *BlockCodec {
+	if c <= 0 || c >= 255 {
+		panic(fmt.Sprintf("rs: invalid ECC length per block: %d", c))
+	}
+	return &BlockCodec{c: c, e: NewEncoder(f, c), d: NewDecoder(f)}
+}
+
+// BlockSize returns the maximum number of data bytes per block.
+func (b *BlockCodec) BlockSize() int {
+	return 255 - b.c
+}
+
+// Blocks returns the number of blocks used to protect n bytes of data.
+func (b *BlockCodec) Blocks(n int) int {
+	k := b.BlockSize()
+	return (n + k - 1) / k
+}
+
+// ECCLen returns the number of ECC bytes needed to protect n bytes of data.
+func (b *BlockCodec) ECCLen(n int) int {
+	return b.Blocks(n) * b.c
+}
+
+// Encode calculates the ECC codes of every blocks of data and writes them
+// into ecc, which must be ECCLen(len(data)) long.
+func (b *BlockCodec) Encode(data, ecc []byte) error {
+	if l := b.ECCLen(len(data)); len(ecc) != l {
+		return fmt.Errorf("%w: expected %d ECC bytes for %d bytes of data, got %d", ErrInvalidLength, l, len(data), len(ecc))
+	}
+	k := b.BlockSize()
+	for i := 0; i < len(data); i += k {
+		e := i + k
+		if e > len(data) {
+			e = len(data)
+		}
+		b.e.Encode(data[i:e], ecc[i/k*b.c:(i/k+1)*b.c])
+	}
+	return nil
+}
+
+// Decode corrects every block in-place.
+//
+// Returns the number of errors corrected in each block. All the blocks are
+// processed even if some fail, in which case their count is -1 and the error
+// of the first failed block is returned.
+func (b *BlockCodec) Decode(data, ecc []byte) ([]int, error) {
+	if l := b.ECCLen(len(data)); len(ecc) != l {
+		return nil, fmt.Errorf("%w: expected %d ECC bytes for %d bytes of data, got %d", ErrInvalidLength, l, len(data), len(ecc))
+	}
+	k := b.BlockSize()
+	counts := make([]int, b.Blocks(len(data)))
+	var err error
+	for i := range counts {
+		e := (i + 1) * k
+		if e > len(data) {
+			e = len(data)
+		}
+		n, err2 := b.d.Decode(data[i*k:e], ecc[i*b.c:(i+1)*b.c])
+		if err2 != nil {
+			n = -1
+			if err == nil {
+				err = &BlockError{i, err2}
+			}
+		}
+		counts[i] = n
+	}
+	return counts, err
+}
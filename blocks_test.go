@@ -0,0 +1,142 @@
+/* Copyright 2012 Marc-Antoine Ruel. Licensed under the Apache License, Version
+2.0 (the "License"); you may not use this file except in compliance with the
+License.  You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0. Unless required by applicable law or
+agreed to in writing, software distributed under the License is distributed on
+an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+or implied. See the License for the specific language governing permissions and
+limitations under the License. */
+
+package rs
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+func TestBlockCodecSizes(t *testing.T) {
+	b := NewBlockCodec(QRCodeField256, 32)
+	if b.BlockSize() != 223 {
+		t.Fatal(b.BlockSize())
+	}
+	data := []struct {
+		n      int
+		blocks int
+	}{
+		{0, 0},
+		{1, 1},
+		{223, 1},
+		{224, 2},
+		{10000, 45},
+	}
+	for _, line := range data {
+		if b.Blocks(line.n) != line.blocks {
+			t.Fatalf("Blocks(%d) = %d, expected %d", line.n, b.Blocks(line.n), line.blocks)
+		}
+		if b.ECCLen(line.n) != line.blocks*32 {
+			t.Fatalf("ECCLen(%d) = %d", line.n, b.ECCLen(line.n))
+		}
+	}
+}
+
+func TestBlockCodec(t *testing.T) {
+	b := NewBlockCodec(QRCodeField256, 16)
+	golden := make([]byte, 10000)
+	for i := range golden {
+		golden[i] = byte(rand.Intn(256))
+	}
+	ecc := make([]byte, b.ECCLen(len(golden)))
+	if err := b.Encode(golden, ecc); err != nil {
+		t.Fatal(err)
+	}
+	goldenECC := makecopy(ecc)
+	data := makecopy(golden)
+	for i := 0; i < b.Blocks(len(data)); i++ {
+		start := i * b.BlockSize()
+		end := start + b.BlockSize()
+		if end > len(data) {
+			end = len(data)
+		}
+		corrupt(data[start:end], i%9)
+	}
+	counts, err := b.Decode(data, ecc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, c := range counts {
+		if c != i%9 {
+			t.Fatalf("block %d: expected %d errors, got %d", i, i%9, c)
+		}
+	}
+	compare(t, data, golden, "Data differs")
+	compare(t, ecc, goldenECC, "ECC differs")
+}
+
+func TestBlockCodecFailure(t *testing.T) {
+	b := NewBlockCodec(QRCodeField256, 4)
+	golden := make([]byte, 1000)
+	for i := range golden {
+		golden[i] = byte(i * 7)
+	}
+	ecc := make([]byte, b.ECCLen(len(golden)))
+	if err := b.Encode(golden, ecc); err != nil {
+		t.Fatal(err)
+	}
+	data := makecopy(golden)
+	data[0] ^= 1
+	// Too many errors in the second block.
+	data[b.BlockSize()] ^= 1
+	data[b.BlockSize()+1] ^= 1
+	data[b.BlockSize()+2] ^= 1
+	data[len(data)-1] ^= 1
+	counts, err := b.Decode(data, ecc)
+	var blockErr *BlockError
+	if !errors.As(err, &blockErr) || blockErr.Block != 1 {
+		t.Fatalf("expected failure of block 1: %v", err)
+	}
+	if len(counts) != 4 || counts[0] != 1 || counts[1] != -1 || counts[3] != 1 {
+		t.Fatal(counts)
+	}
+	if !bytes.Equal(data[:b.BlockSize()], golden[:b.BlockSize()]) {
+		t.Fatal("first block not corrected")
+	}
+}
+
+func TestBlockCodecInvalid(t *testing.T) {
+	b := NewBlockCodec(QRCodeField256, 4)
+	if b.Encode(make([]byte, 10), make([]byte, 3)) == nil {
+		t.Fatal("expected failure")
+	}
+	if _, err := b.Decode(make([]byte, 10), make([]byte, 8)); !errors.Is(err, ErrInvalidLength) {
+		t.Fatal("expected failure")
+	}
+	for _, c := range []int{0, 255} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("%d: expected panic", c)
+				}
+			}()
+			NewBlockCodec(QRCodeField256, c)
+		}()
+	}
+}
+
+func BenchmarkBlockCodecEncode64K_32(b *testing.B) {
+	b.StopTimer()
+	c := NewBlockCodec(QRCodeField256, 32)
+	data := make([]byte, 65536)
+	for i := range data {
+		data[i] = Rand128[i%len(Rand128)]
+	}
+	ecc := make([]byte, c.ECCLen(len(data)))
+	b.SetBytes(int64(len(data)))
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		if err := c.Encode(data, ecc); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+/* Copyright 2012 Marc-Antoine Ruel. Licensed under the Apache License, Version
+2.0 (the "License"); you may not use this file except in compliance with the
+License.  You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0. Unless required by applicable law or
+agreed to in writing, software distributed under the License is distributed on
+an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+or implied. See the License for the specific language governing permissions and
+limitations under the License. */
+
+package rs
+
+import (
+	"fmt"
+)
+
+// CCSDSField256 is the Galois field used by the CCSDS Reed-Solomon code. See
+// CCSDS 131.0-B "TM Synchronization and Channel Coding", section 4.
+//
+// x^8 + x^7 + x^2 + x + 1
+var CCSDSField256 = NewField(0x187, 2)
+
+// CCSDSParity is the number of check symbols per CCSDS codeword.
+const CCSDSParity = 32
+
+// CCSDS implements the CCSDS 131.0-B (255,223) Reed-Solomon code with E=16,
+// including the symbol interleaving and the dual basis representation used
+// on the wire.
+//
+// The generator polynomial roots are α^(11*j) for j in [112, 143].
+//
+// A codeblock of interleaving depth I contains I codewords; symbol i belongs
+// to codeword i%I. The data may be shortened, in which case it is considered
+// to be prefixed with the virtual fill of zeros.
+type CCSDS struct {
+	depth int
+	e     Encoder
+	d     Decoder
+}
+
+// NewCCSDS returns the CCSDS code with interleaving depth I between 1 and 8
+// inclusively.
+func NewCCSDS(depth int) *CCSDS {
+	if depth < 1 || depth > 8 {
+		panic(fmt.Sprintf("rs: invalid CCSDS interleaving depth: %d", depth))
+	}
+	opts := []Option{FirstRoot(112), RootSpacing(11)}
+	return &CCSDS{
+		depth: depth,
+		e:     NewEncoder(CCSDSField256, CCSDSParity, opts...),
+		d:     NewDecoder(CCSDSField256, opts...),
+	}
+}
+
+// Depth returns the interleaving depth I.
+func (c *CCSDS) Depth() int {
+	return c.depth
+}
+
+// Encode calculates the check symbols of the interleaved data, both in dual
+// basis representation.
+//
+// data must be a multiple of I long, up to 223*I. parity must be 32*I long.
+func (c *CCSDS) Encode(data, parity []byte) error {
+	k, err := c.check(data, parity)
+	if err != nil {
+		return err
+	}
+	buf := make([]byte, k)
+	ecc := make([]byte, CCSDSParity)
+	for j := 0; j < c.depth; j++ {
+		for i := range buf {
+			buf[i] = ccsdsFromDual[data[i*c.depth+j]]
+		}
+		c.e.Encode(buf, ecc)
+		for i, v := range ecc {
+			parity[i*c.depth+j] = ccsdsToDual[v]
+		}
+	}
+	return nil
+}
+
+// Decode corrects the interleaved codewords in-place.
+//
+// Returns the number of errors corrected in each codeword. All the codewords
+// are processed even if some fail, in which case their count is -1 and the
+// error of the first failed codeword is returned.
+func (c *CCSDS) Decode(data, parity []byte) ([]int, error) {
+	k, err := c.check(data, parity)
+	if err != nil {
+		return nil, err
+	}
+	buf := make([]byte, k)
+	ecc := make([]byte, CCSDSParity)
+	counts := make([]int, c.depth)
+	for j := range counts {
+		for i := range buf {
+			buf[i] = ccsdsFromDual[data[i*c.depth+j]]
+		}
+		for i := range ecc {
+			ecc[i] = ccsdsFromDual[parity[i*c.depth+j]]
+		}
+		n, err2 := c.d.Decode(buf, ecc)
+		if err2 != nil {
+			counts[j] = -1
+			if err == nil {
+				err = &BlockError{j, err2}
+			}
+			continue
+		}
+		counts[j] = n
+		for i, v := range buf {
+			data[i*c.depth+j] = ccsdsToDual[v]
+		}
+		for i, v := range ecc {
+			parity[i*c.depth+j] = ccsdsToDual[v]
+		}
+	}
+	return counts, err
+}
+
+// check verifies the buffer sizes and returns the number of data symbols per
+// codeword.
+func (c *CCSDS) check(data, parity []byte) (int, error) {
+	if len(data)%c.depth != 0 || len(data) > (255-CCSDSParity)*c.depth {
+		return 0, fmt.Errorf("%w: CCSDS data length %d for depth %d", ErrInvalidLength, len(data), c.depth)
+	}
+	if len(parity) != CCSDSParity*c.depth {
+		return 0, fmt.Errorf("%w: expected %d CCSDS check symbols, got %d", ErrInvalidLength, CCSDSParity*c.depth, len(parity))
+	}
+	return len(data) / c.depth, nil
+}
+
+// ccsdsToDual converts a symbol from the conventional representation to
+// Berlekamp's dual basis representation, ccsdsFromDual does the reverse.
+var ccsdsToDual, ccsdsFromDual = makeCCSDSDualBasis()
+
+// makeCCSDSDualBasis calculates the transformation tables from the matrix
+// given in CCSDS 131.0-B.
+func makeCCSDSDualBasis() (toDual, fromDual [256]byte) {
+	// Rows of the transformation matrix, from the most significant bit.
+	tal := [8]byte{0x8d, 0xef, 0xec, 0x86, 0xfa, 0x99, 0xaf, 0x7b}
+	for i := 0; i < 256; i++ {
+		for k := uint(0); k < 8; k++ {
+			if i&(1<<k) != 0 {
+				toDual[i] ^= tal[7-k]
+			}
+		}
+		fromDual[toDual[i]] = byte(i)
+	}
+	return
+}
@@ -0,0 +1,247 @@
+/* Copyright 2012 Marc-Antoine Ruel. Licensed under the Apache License, Version
+2.0 (the "License"); you may not use this file except in compliance with the
+License.  You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0. Unless required by applicable law or
+agreed to in writing, software distributed under the License is distributed on
+an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+or implied. See the License for the specific language governing permissions and
+limitations under the License. */
+
+package rs
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// The generator polynomial coefficients, as powers of α, published in CCSDS
+// 131.0-B section 4. The polynomial is symmetric.
+var ccsdsGenerator = []int{
+	0, 249, 59, 66, 4, 43, 126, 251, 97, 30, 3, 213, 50, 66, 170, 5,
+	24,
+	5, 170, 66, 50, 213, 3, 30, 97, 251, 126, 43, 4, 66, 59, 249, 0,
+}
+
+func TestCCSDSGenerator(t *testing.T) {
+	gen := NewCCSDS(1).e.(*rootsEncoder).gen
+	if len(gen) != CCSDSParity {
+		t.Fatal(len(gen))
+	}
+	// The leading 1 term is implicit.
+	for i, c := range gen {
+		if l := CCSDSField256.f.Log(c); l != ccsdsGenerator[i+1] {
+			t.Fatalf("g_%d = α^%d, expected α^%d", CCSDSParity-1-i, l, ccsdsGenerator[i+1])
+		}
+	}
+}
+
+// Compares with the first entries of Taltab and Tal1tab in Phil Karn's libfec
+// ccsds_tab.c.
+func TestCCSDSDualBasis(t *testing.T) {
+	toDual := []byte{0x00, 0x7b, 0xaf, 0xd4, 0x99, 0xe2, 0x36, 0x4d, 0xfa, 0x81, 0x55, 0x2e, 0x63, 0x18, 0xcc, 0xb7}
+	fromDual := []byte{0x00, 0xcc, 0xac, 0x60, 0x79, 0xb5, 0xd5, 0x19, 0xf0, 0x3c, 0x5c, 0x90, 0x89, 0x45, 0x25, 0xe9}
+	compare(t, toDual, ccsdsToDual[:16], "conventional to dual basis differs")
+	compare(t, fromDual, ccsdsFromDual[:16], "dual to conventional basis differs")
+	for i := 0; i < 256; i++ {
+		if ccsdsFromDual[ccsdsToDual[i]] != byte(i) {
+			t.Fatalf("%d: not reversible", i)
+		}
+	}
+}
+
+func TestCCSDS(t *testing.T) {
+	for depth := 1; depth <= 8; depth++ {
+		c := NewCCSDS(depth)
+		if c.Depth() != depth {
+			t.Fatal(c.Depth())
+		}
+		// Full length then shortened codewords.
+		for _, k := range []int{223, 100} {
+			golden := make([]byte, k*depth)
+			for i := range golden {
+				golden[i] = byte(rand.Intn(256))
+			}
+			goldenParity := make([]byte, CCSDSParity*depth)
+			if err := c.Encode(golden, goldenParity); err != nil {
+				t.Fatal(err)
+			}
+			data := makecopy(golden)
+			parity := makecopy(goldenParity)
+			// A burst of 16*depth symbols is spread over all the codewords.
+			start := rand.Intn(len(data) - 16*depth)
+			for i := start; i < start+16*depth; i++ {
+				data[i] ^= byte(1 + rand.Intn(255))
+			}
+			counts, err := c.Decode(data, parity)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for j, n := range counts {
+				if n != 16 {
+					t.Fatalf("depth %d, codeword %d: expected 16 errors, got %d", depth, j, n)
+				}
+			}
+			compare(t, data, golden, "Data differs")
+			compare(t, parity, goldenParity, "Parity differs")
+		}
+	}
+}
+
+// ccsdsReference encodes the codeword of conventional basis data with the
+// published generator polynomial and returns the whole codeword in dual basis,
+// as defined by CCSDS 131.0-B section 4. It doesn't use Encoder nor the dual
+// basis tables.
+func ccsdsReference(data []byte) []byte {
+	f := CCSDSField256.f
+	// Systematic encoding: the check symbols are data·x^32 mod g(x).
+	rem := make([]byte, CCSDSParity)
+	for _, v := range data {
+		feedback := v ^ rem[0]
+		copy(rem, rem[1:])
+		rem[CCSDSParity-1] = 0
+		if feedback != 0 {
+			for i := range rem {
+				rem[i] ^= f.Mul(feedback, f.Exp(ccsdsGenerator[i+1]))
+			}
+		}
+	}
+	// [z0..z7] = [x0..x7]·T, x0 and z0 being the most significant bits.
+	T := [8][8]byte{
+		{1, 0, 0, 0, 1, 1, 0, 1},
+		{1, 1, 1, 0, 1, 1, 1, 1},
+		{1, 1, 1, 0, 1, 1, 0, 0},
+		{1, 0, 0, 0, 0, 1, 1, 0},
+		{1, 1, 1, 1, 1, 0, 1, 0},
+		{1, 0, 0, 1, 1, 0, 0, 1},
+		{1, 0, 1, 0, 1, 1, 1, 1},
+		{0, 1, 1, 1, 1, 0, 1, 1},
+	}
+	out := append(append([]byte{}, data...), rem...)
+	for n, x := range out {
+		z := byte(0)
+		for col := 0; col < 8; col++ {
+			bit := byte(0)
+			for row := 0; row < 8; row++ {
+				bit ^= (x >> uint(7-row)) & 1 & T[row][col]
+			}
+			z |= bit << uint(7-col)
+		}
+		out[n] = z
+	}
+	return out
+}
+
+// A full codeblock of interleaving depth 5 encoded by ccsdsReference. It is
+// not a published vector; it checks Encode and Decode against an independent
+// reading of CCSDS 131.0-B, including the interleaving and the dual basis.
+func TestCCSDSReferenceCodeblock(t *testing.T) {
+	const depth = 5
+	data := make([]byte, 223*depth)
+	parity := make([]byte, CCSDSParity*depth)
+	for j := 0; j < depth; j++ {
+		conventional := make([]byte, 223)
+		for i := range conventional {
+			conventional[i] = byte(i*7 + j*31 + 1)
+		}
+		codeword := ccsdsReference(conventional)
+		for i, v := range codeword {
+			if i < 223 {
+				data[i*depth+j] = v
+			} else {
+				parity[(i-223)*depth+j] = v
+			}
+		}
+	}
+	compare(t, ccsdsReferenceParity, parity, "Reference parity differs")
+	c := NewCCSDS(depth)
+	got := make([]byte, len(parity))
+	if err := c.Encode(data, got); err != nil {
+		t.Fatal(err)
+	}
+	compare(t, parity, got, "Parity differs")
+	// 16 errors per codeword, in both data and parity.
+	corrupted := makecopy(data)
+	for i := 100; i < 100+12*depth; i++ {
+		corrupted[i] ^= 0x5A
+	}
+	for i := 0; i < 4*depth; i++ {
+		got[i] ^= 0xA5
+	}
+	counts, err := c.Decode(corrupted, got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for j, n := range counts {
+		if n != 16 {
+			t.Fatalf("codeword %d: expected 16 errors, got %d", j, n)
+		}
+	}
+	compare(t, data, corrupted, "Data differs")
+	compare(t, parity, got, "Parity differs")
+}
+
+func TestCCSDSFailure(t *testing.T) {
+	c := NewCCSDS(2)
+	golden := make([]byte, 2*223)
+	parity := make([]byte, 2*CCSDSParity)
+	if err := c.Encode(golden, parity); err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range parity {
+		if v != 0 {
+			t.Fatal("all zeros data must have all zeros parity")
+		}
+	}
+	data := makecopy(golden)
+	// 18 errors in the first codeword, 1 in the second.
+	for i := 0; i < 18; i++ {
+		data[2*i] = byte(i + 1)
+	}
+	data[1] = 1
+	counts, err := c.Decode(data, parity)
+	if err == nil {
+		t.Fatal("expected failure")
+	}
+	if counts[0] != -1 || counts[1] != 1 {
+		t.Fatal(counts)
+	}
+}
+
+func TestCCSDSInvalid(t *testing.T) {
+	c := NewCCSDS(4)
+	if c.Encode(make([]byte, 10), make([]byte, 4*CCSDSParity)) == nil {
+		t.Fatal("expected failure")
+	}
+	if c.Encode(make([]byte, 4*224), make([]byte, 4*CCSDSParity)) == nil {
+		t.Fatal("expected failure")
+	}
+	if _, err := c.Decode(make([]byte, 12), make([]byte, CCSDSParity)); err == nil {
+		t.Fatal("expected failure")
+	}
+	for _, depth := range []int{0, 9} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("%d: expected panic", depth)
+				}
+			}()
+			NewCCSDS(depth)
+		}()
+	}
+}
+
+// ccsdsReferenceParity is the parity calculated by ccsdsReference for the
+// codeblock of TestCCSDSReferenceCodeblock, kept to detect regressions of the
+// reference itself.
+var ccsdsReferenceParity = []byte{
+	0x57, 0xaa, 0xd9, 0x73, 0xf7, 0x49, 0x69, 0x32, 0x13, 0x56, 0xb1, 0x79, 0xa8, 0x0a, 0x5f, 0x4d,
+	0x3a, 0x51, 0xae, 0x13, 0x46, 0x69, 0x7f, 0xd9, 0x4e, 0x95, 0x3f, 0xa3, 0x85, 0x51, 0x8f, 0x8b,
+	0xdd, 0xf0, 0x22, 0x8d, 0x7f, 0x5c, 0x3c, 0xd3, 0xe5, 0x63, 0x1a, 0xbb, 0xff, 0x82, 0xc1, 0x46,
+	0xac, 0x80, 0x0e, 0x80, 0x7b, 0xb0, 0x1c, 0xfe, 0xa5, 0x3c, 0x72, 0x6c, 0x31, 0x20, 0xc7, 0x60,
+	0xb3, 0x3a, 0xec, 0x69, 0x5c, 0x72, 0xe1, 0xe2, 0x91, 0xd0, 0xa0, 0x32, 0xed, 0xd8, 0x8f, 0xde,
+	0x25, 0x39, 0xa7, 0x44, 0x54, 0x42, 0xc9, 0x7e, 0xa0, 0x43, 0x5b, 0xe7, 0x38, 0x4d, 0x41, 0xb9,
+	0x67, 0xe3, 0x3d, 0x9b, 0xc0, 0xdb, 0x35, 0x7d, 0x29, 0x29, 0x74, 0xad, 0x85, 0x60, 0x59, 0xcd,
+	0x88, 0x03, 0x49, 0x65, 0x37, 0x19, 0xd2, 0x99, 0x29, 0x9f, 0xc9, 0xce, 0x0a, 0xc5, 0xf4, 0x6c,
+	0x0f, 0x99, 0x0f, 0xce, 0xe0, 0xa1, 0xf5, 0x41, 0xf0, 0x38, 0xb0, 0xe8, 0x4a, 0x56, 0x61, 0x16,
+	0x88, 0xd3, 0x7e, 0x3c, 0x35, 0x69, 0x3f, 0xfd, 0x09, 0x0d, 0xff, 0x6d, 0x2a, 0xe8, 0x68, 0x4d,
+}
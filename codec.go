@@ -0,0 +1,110 @@
+/* Copyright 2012 Marc-Antoine Ruel. Licensed under the Apache License, Version
+2.0 (the "License"); you may not use this file except in compliance with the
+License.  You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0. Unless required by applicable law or
+agreed to in writing, software distributed under the License is distributed on
+an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+or implied. See the License for the specific language governing permissions and
+limitations under the License. */
+
+package rs
+
+import (
+	"fmt"
+)
+
+// Codec is a systematic RS(n,k) code: codewords of n bytes made of k data
+// bytes followed by n-k ECC bytes.
+//
+// Unlike Encoder and Decoder, it validates the buffer lengths and returns an
+// error instead of misbehaving.
+type Codec struct {
+	n int
+	k int
+	e Encoder
+	d Decoder
+}
+
+// NewCodec returns a Codec for RS(n,k) codewords in the field f.
+//
+// n must be at most 255 and k between 1 and n-1 inclusively. The options are
+// the same as NewEncoder and NewDecoder.
+func NewCodec(f *Field, n, k int, opts ...Option) *Codec {
+	if n > 255 || k <= 0 || k >= n {
+		panic(fmt.Sprintf("rs: invalid RS(%d,%d) code", n, k))
+	}
+	return &Codec{n: n, k: k, e: NewEncoder(f, n-k, opts...), d: NewDecoder(f, opts...)}
+}
+
+// N returns the number of bytes in a codeword.
+func (c *Codec) N() int {
+	return c.n
+}
+
+// K returns the number of data bytes in a codeword.
+func (c *Codec) K() int {
+	return c.k
+}
+
+// ECCLen returns the number of ECC bytes in a codeword, n-k.
+func (c *Codec) ECCLen() int {
+	return c.n - c.k
+}
+
+// MaxErrors returns the maximum number of errors that can be corrected in a
+// codeword when there is no erasure, (n-k)/2.
+func (c *Codec) MaxErrors() int {
+	return (c.n - c.k) / 2
+}
+
+// Rate returns the code rate k/n.
+func (c *Codec) Rate() float64 {
+	return float64(c.k) / float64(c.n)
+}
+
+// Encode calculates the ECC bytes of data into ecc.
+//
+// data must be K() bytes long and ecc ECCLen() bytes long.
+func (c *Codec) Encode(data, ecc []byte) error {
+	if err := c.check(data, ecc); err != nil {
+		return err
+	}
+	return c.e.EncodeChecked(data, ecc)
+}
+
+// Decode corrects errors in-place. See Decoder.Decode.
+func (c *Codec) Decode(data, ecc []byte) (int, error) {
+	if err := c.check(data, ecc); err != nil {
+		return 0, err
+	}
+	return c.d.Decode(data, ecc)
+}
+
+// DecodeErasures corrects errors and erasures in-place. See
+// Decoder.DecodeErasures.
+func (c *Codec) DecodeErasures(data, ecc []byte, erasures []int) (nbErrors, nbErasures int, err error) {
+	if err := c.check(data, ecc); err != nil {
+		return 0, 0, err
+	}
+	return c.d.DecodeErasures(data, ecc, erasures)
+}
+
+// Verify returns true if data and ecc form a valid codeword. See
+// Decoder.Verify.
+func (c *Codec) Verify(data, ecc []byte) (bool, error) {
+	if err := c.check(data, ecc); err != nil {
+		return false, err
+	}
+	return c.d.Verify(data, ecc)
+}
+
+// check verifies the buffer sizes.
+func (c *Codec) check(data, ecc []byte) error {
+	if len(data) != c.k {
+		return fmt.Errorf("%w: expected %d data bytes for RS(%d,%d), got %d", ErrInvalidLength, c.k, c.n, c.k, len(data))
+	}
+	if len(ecc) != c.n-c.k {
+		return fmt.Errorf("%w: expected %d ECC bytes for RS(%d,%d), got %d", ErrInvalidLength, c.n-c.k, c.n, c.k, len(ecc))
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+/* Copyright 2012 Marc-Antoine Ruel. Licensed under the Apache License, Version
+2.0 (the "License"); you may not use this file except in compliance with the
+License.  You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0. Unless required by applicable law or
+agreed to in writing, software distributed under the License is distributed on
+an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+or implied. See the License for the specific language governing permissions and
+limitations under the License. */
+
+package rs
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCodec(t *testing.T) {
+	c := NewCodec(QRCodeField256, 26, 16)
+	if c.N() != 26 || c.K() != 16 || c.ECCLen() != 10 || c.MaxErrors() != 5 {
+		t.Fatal(c.N(), c.K(), c.ECCLen(), c.MaxErrors())
+	}
+	if r := c.Rate(); r < 0.615 || r > 0.616 {
+		t.Fatal(r)
+	}
+	data := makecopy(QRCodeTestData)
+	ecc := make([]byte, c.ECCLen())
+	if err := c.Encode(data, ecc); err != nil {
+		t.Fatal(err)
+	}
+	compare(t, ecc, QRCodeTestECC, "ECC differs")
+	if ok, err := c.Verify(data, ecc); !ok || err != nil {
+		t.Fatal(ok, err)
+	}
+	data[3] ^= 0x11
+	ecc[2] ^= 0x22
+	if ok, err := c.Verify(data, ecc); ok || err != nil {
+		t.Fatal(ok, err)
+	}
+	if n, err := c.Decode(data, ecc); n != 2 || err != nil {
+		t.Fatal(n, err)
+	}
+	compare(t, data, QRCodeTestData, "Data differs")
+	compare(t, ecc, QRCodeTestECC, "ECC differs")
+	data[0] ^= 0x33
+	if n, m, err := c.DecodeErasures(data, ecc, []int{0, 1}); n != 0 || m != 1 || err != nil {
+		t.Fatal(n, m, err)
+	}
+	compare(t, data, QRCodeTestData, "Data differs")
+}
+
+func TestCodecInvalid(t *testing.T) {
+	c := NewCodec(QRCodeField256, 26, 16)
+	if err := c.Encode(make([]byte, 15), make([]byte, 10)); !errors.Is(err, ErrInvalidLength) {
+		t.Fatal(err)
+	}
+	if _, err := c.Decode(make([]byte, 16), make([]byte, 11)); !errors.Is(err, ErrInvalidLength) {
+		t.Fatal(err)
+	}
+	if _, _, err := c.DecodeErasures(make([]byte, 17), make([]byte, 10), nil); !errors.Is(err, ErrInvalidLength) {
+		t.Fatal(err)
+	}
+	if _, err := c.Verify(make([]byte, 16), nil); !errors.Is(err, ErrInvalidLength) {
+		t.Fatal(err)
+	}
+	for _, line := range []struct{ n, k int }{{256, 200}, {26, 0}, {26, 26}, {26, 30}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("RS(%d,%d): expected panic", line.n, line.k)
+				}
+			}()
+			NewCodec(QRCodeField256, line.n, line.k)
+		}()
+	}
+}
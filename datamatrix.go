@@ -0,0 +1,208 @@
+/* Copyright 2012 Marc-Antoine Ruel. Licensed under the Apache License, Version
+2.0 (the "License"); you may not use this file except in compliance with the
+License.  You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0. Unless required by applicable law or
+agreed to in writing, software distributed under the License is distributed on
+an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+or implied. See the License for the specific language governing permissions and
+limitations under the License. */
+
+package rs
+
+import (
+	"fmt"
+)
+
+// DataMatrixField256 is the Galois field used by Data Matrix ECC200, as
+// defined in ISO/IEC 16022.
+//
+// x^8 + x^5 + x^3 + x^2 + 1
+var DataMatrixField256 = NewField(0x12D, 2)
+
+// DataMatrixCodec implements the block structure of a Data Matrix ECC200
+// symbol size, as defined in ISO/IEC 16022, section 5.7.
+//
+// The generator polynomial roots are α^1 to α^c. Larger symbols split the
+// codewords into interleaved blocks: the codeword at position p of the data
+// codewords followed by the ECC codewords belongs to block p%Blocks().
+type DataMatrixCodec struct {
+	rows   int
+	cols   int
+	data   int // Total number of data codewords.
+	blocks int
+	ecc    int // ECC codewords per block.
+	e      Encoder
+	d      Decoder
+}
+
+// NewDataMatrixCodec returns the DataMatrixCodec for the ECC200 symbol size
+// rows x cols, from 10x10 to 144x144 and 8x18 to 16x48.
+func NewDataMatrixCodec(rows, cols int) *DataMatrixCodec {
+	for _, s := range dataMatrixSizes {
+		if s.rows == rows && s.cols == cols {
+			return &DataMatrixCodec{
+				rows:   rows,
+				cols:   cols,
+				data:   s.data,
+				blocks: s.blocks,
+				ecc:    s.ecc,
+				e:      NewEncoder(DataMatrixField256, s.ecc, FirstRoot(1)),
+				d:      NewDecoder(DataMatrixField256, FirstRoot(1)),
+			}
+		}
+	}
+	panic(fmt.Sprintf("rs: invalid Data Matrix symbol size %dx%d", rows, cols))
+}
+
+// Rows returns the number of rows of the symbol.
+func (m *DataMatrixCodec) Rows() int {
+	return m.rows
+}
+
+// Cols returns the number of columns of the symbol.
+func (m *DataMatrixCodec) Cols() int {
+	return m.cols
+}
+
+// Len returns the total number of codewords.
+func (m *DataMatrixCodec) Len() int {
+	return m.data + m.blocks*m.ecc
+}
+
+// DataLen returns the number of data codewords.
+func (m *DataMatrixCodec) DataLen() int {
+	return m.data
+}
+
+// Blocks returns the number of interleaved blocks.
+func (m *DataMatrixCodec) Blocks() int {
+	return m.blocks
+}
+
+// ECCPerBlock returns the number of ECC codewords of each block.
+func (m *DataMatrixCodec) ECCPerBlock() int {
+	return m.ecc
+}
+
+// BlockDataLen returns the number of data codewords of block i. Only the
+// 144x144 symbol has blocks of different sizes.
+func (m *DataMatrixCodec) BlockDataLen(i int) int {
+	return (m.data - i + m.blocks - 1) / m.blocks
+}
+
+// Encode returns the Len() codewords of the DataLen() data codewords.
+func (m *DataMatrixCodec) Encode(data []byte) ([]byte, error) {
+	if len(data) != m.data {
+		return nil, fmt.Errorf("%w: expected %d data codewords for Data Matrix %dx%d, got %d", ErrInvalidLength, m.data, m.rows, m.cols, len(data))
+	}
+	out := make([]byte, m.Len())
+	copy(out, data)
+	block := make([]byte, m.BlockDataLen(0))
+	ecc := make([]byte, m.ecc)
+	for b := 0; b < m.blocks; b++ {
+		block = block[:m.BlockDataLen(b)]
+		m.gather(block, ecc, out, b)
+		m.e.Encode(block, ecc)
+		m.scatter(out, block, ecc, b)
+	}
+	return out, nil
+}
+
+// Decode corrects the Len() codewords and returns the DataLen() data
+// codewords. codewords is not modified.
+//
+// Returns the number of errors corrected in each block. All the blocks are
+// processed even if some fail, in which case their count is -1 and a
+// *BlockError for the first failed block is returned along the data.
+func (m *DataMatrixCodec) Decode(codewords []byte) ([]byte, []int, error) {
+	if len(codewords) != m.Len() {
+		return nil, nil, fmt.Errorf("%w: expected %d codewords for Data Matrix %dx%d, got %d", ErrInvalidLength, m.Len(), m.rows, m.cols, len(codewords))
+	}
+	out := make([]byte, len(codewords))
+	copy(out, codewords)
+	block := make([]byte, m.BlockDataLen(0))
+	ecc := make([]byte, m.ecc)
+	counts := make([]int, m.blocks)
+	var err error
+	for b := range counts {
+		block = block[:m.BlockDataLen(b)]
+		m.gather(block, ecc, out, b)
+		n, err2 := m.d.Decode(block, ecc)
+		if err2 != nil {
+			n = -1
+			if err == nil {
+				err = &BlockError{b, err2}
+			}
+		} else {
+			m.scatter(out, block, ecc, b)
+		}
+		counts[b] = n
+	}
+	return out[:m.data], counts, err
+}
+
+// gather copies the data and ecc codewords of block b from in.
+func (m *DataMatrixCodec) gather(data, ecc, in []byte, b int) {
+	for i := range data {
+		data[i] = in[i*m.blocks+b]
+	}
+	for i := range ecc {
+		ecc[i] = in[m.eccPos(i, b)]
+	}
+}
+
+// scatter is the reverse of gather.
+func (m *DataMatrixCodec) scatter(out, data, ecc []byte, b int) {
+	for i, v := range data {
+		out[i*m.blocks+b] = v
+	}
+	for i, v := range ecc {
+		out[m.eccPos(i, b)] = v
+	}
+}
+
+// eccPos returns the position of the ECC codeword i of block b.
+func (m *DataMatrixCodec) eccPos(i, b int) int {
+	// The first ECC codeword of block b is the first position p >= m.data
+	// where p%m.blocks == b.
+	return m.data + mod(b-m.data, m.blocks) + i*m.blocks
+}
+
+// dataMatrixSizes is ISO/IEC 16022 table 7.
+var dataMatrixSizes = []struct {
+	rows, cols int
+	data       int // Total number of data codewords.
+	blocks     int
+	ecc        int // ECC codewords per block.
+}{
+	{10, 10, 3, 1, 5},
+	{12, 12, 5, 1, 7},
+	{14, 14, 8, 1, 10},
+	{16, 16, 12, 1, 12},
+	{18, 18, 18, 1, 14},
+	{20, 20, 22, 1, 18},
+	{22, 22, 30, 1, 20},
+	{24, 24, 36, 1, 24},
+	{26, 26, 44, 1, 28},
+	{32, 32, 62, 1, 36},
+	{36, 36, 86, 1, 42},
+	{40, 40, 114, 1, 48},
+	{44, 44, 144, 1, 56},
+	{48, 48, 174, 1, 68},
+	{52, 52, 204, 2, 42},
+	{64, 64, 280, 2, 56},
+	{72, 72, 368, 4, 36},
+	{80, 80, 456, 4, 48},
+	{88, 88, 576, 4, 56},
+	{96, 96, 696, 4, 68},
+	{104, 104, 816, 6, 56},
+	{120, 120, 1050, 6, 68},
+	{132, 132, 1304, 8, 62},
+	{144, 144, 1558, 10, 62},
+	{8, 18, 5, 1, 7},
+	{8, 32, 10, 1, 11},
+	{12, 26, 16, 1, 14},
+	{12, 36, 22, 1, 18},
+	{16, 36, 32, 1, 24},
+	{16, 48, 49, 1, 28},
+}
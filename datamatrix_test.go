@@ -0,0 +1,125 @@
+/* Copyright 2012 Marc-Antoine Ruel. Licensed under the Apache License, Version
+2.0 (the "License"); you may not use this file except in compliance with the
+License.  You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0. Unless required by applicable law or
+agreed to in writing, software distributed under the License is distributed on
+an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+or implied. See the License for the specific language governing permissions and
+limitations under the License. */
+
+package rs
+
+import (
+	"errors"
+	"testing"
+)
+
+// Tests the example "123456" of ISO/IEC 16022, Annex O, in a 10x10 symbol.
+func TestDataMatrixISO16022(t *testing.T) {
+	m := NewDataMatrixCodec(10, 10)
+	if m.Len() != 8 || m.DataLen() != 3 || m.Blocks() != 1 || m.ECCPerBlock() != 5 {
+		t.Fatal(m.Len(), m.DataLen(), m.Blocks(), m.ECCPerBlock())
+	}
+	out, err := m.Encode([]byte{142, 164, 186})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []byte{142, 164, 186, 114, 25, 5, 88, 102}
+	compare(t, out, expected, "Codewords differ")
+	out[1] = 0
+	out[6] = 0
+	data, counts, err := m.Decode(out)
+	if err != nil || counts[0] != 2 {
+		t.Fatal(counts, err)
+	}
+	compare(t, data, expected[:3], "Data differs")
+}
+
+func TestDataMatrixSizes(t *testing.T) {
+	for _, s := range dataMatrixSizes {
+		m := NewDataMatrixCodec(s.rows, s.cols)
+		if m.Rows() != s.rows || m.Cols() != s.cols {
+			t.Fatal(m.Rows(), m.Cols())
+		}
+		total := 0
+		for b := 0; b < m.Blocks(); b++ {
+			total += m.BlockDataLen(b)
+			if m.BlockDataLen(b)+m.ECCPerBlock() > 255 {
+				t.Fatalf("%dx%d: block %d too long", s.rows, s.cols, b)
+			}
+		}
+		if total != m.DataLen() {
+			t.Fatalf("%dx%d: %d != %d", s.rows, s.cols, total, m.DataLen())
+		}
+		data := make([]byte, m.DataLen())
+		for i := range data {
+			data[i] = Rand128[i%len(Rand128)]
+		}
+		out, err := m.Encode(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Every block is a valid codeword.
+		d := NewDecoder(DataMatrixField256, FirstRoot(1))
+		for b := 0; b < m.Blocks(); b++ {
+			block := make([]byte, m.BlockDataLen(b))
+			ecc := make([]byte, m.ECCPerBlock())
+			m.gather(block, ecc, out, b)
+			if ok, err := d.Verify(block, ecc); !ok || err != nil {
+				t.Fatalf("%dx%d: block %d is invalid", s.rows, s.cols, b)
+			}
+		}
+	}
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic")
+		}
+	}()
+	NewDataMatrixCodec(10, 12)
+}
+
+// In the 144x144 symbol, the first 8 blocks have 156 data codewords and the
+// last 2 have 155. The first ECC codeword belongs to block 8.
+func TestDataMatrix144(t *testing.T) {
+	m := NewDataMatrixCodec(144, 144)
+	if m.Len() != 2178 || m.BlockDataLen(7) != 156 || m.BlockDataLen(8) != 155 {
+		t.Fatal(m.Len(), m.BlockDataLen(7), m.BlockDataLen(8))
+	}
+	if m.eccPos(0, 8) != 1558 || m.eccPos(0, 0) != 1560 || m.eccPos(61, 7) != 2177 {
+		t.Fatal(m.eccPos(0, 8), m.eccPos(0, 0), m.eccPos(61, 7))
+	}
+	data := make([]byte, m.DataLen())
+	for i := range data {
+		data[i] = Rand128[(i*7)%len(Rand128)]
+	}
+	out, err := m.Encode(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A burst of 310 codewords is 31 errors per block.
+	for i := 1500; i < 1810; i++ {
+		out[i] ^= 0x3C
+	}
+	got, counts, err := m.Decode(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for b, n := range counts {
+		if n != 31 {
+			t.Fatalf("block %d: %d errors", b, n)
+		}
+	}
+	compare(t, got, data, "Data differs")
+	out[3] ^= 0x3C
+	_, counts, err = m.Decode(out)
+	var blockErr *BlockError
+	if !errors.As(err, &blockErr) || blockErr.Block != 3 || counts[3] != -1 {
+		t.Fatal(counts, err)
+	}
+	if _, err := m.Encode(data[1:]); !errors.Is(err, ErrInvalidLength) {
+		t.Fatal(err)
+	}
+	if _, _, err := m.Decode(out[1:]); !errors.Is(err, ErrInvalidLength) {
+		t.Fatal(err)
+	}
+}
@@ -51,65 +51,270 @@ type Decoder interface {
 	//
 	// Returns the number of errors corrected or an error if decoding failed.
 	Decode(data, ecc []byte) (int, error)
+	// DecodeErasures corrects erasures, bytes known to be unreliable, and
+	// errors at unknown positions, in-place in the input. erasures lists the
+	// erased positions, where data is indexed first and ecc follows, i.e.
+	// position len(data) is ecc[0].
+	//
+	// Since their positions are known, erasures cost half as much as errors:
+	// decoding succeeds as long as 2*nbErrors+len(erasures) <= len(ecc).
+	//
+	// Returns the number of errors corrected and the number of erased bytes
+	// that were effectively modified, or an error if decoding failed. The
+	// errors wrap one of the ErrXXX errors of this package.
+	DecodeErasures(data, ecc []byte, erasures []int) (nbErrors, nbErasures int, err error)
+	// DecodeDetailed is the equivalent of DecodeErasures that also reports
+	// which bytes were corrected and by how much. erasures can be nil.
+	DecodeDetailed(data, ecc []byte, erasures []int) (*DecodeResult, error)
+	// DecodeWith is the equivalent of DecodeErasures that works in-place on
+	// data and ecc, using w for its temporary buffers. It doesn't allocate
+	// memory once w has grown to the size of ecc, except to return errors.
+	//
+	// It always uses the Berlekamp-Massey algorithm. erasures can be nil.
+	DecodeWith(w *Workspace, data, ecc []byte, erasures []int) (nbErrors, nbErasures int, err error)
+	// Verify returns true if data and ecc form a valid codeword, e.g. all the
+	// syndromes are zero. It doesn't modify its input nor allocate memory,
+	// except to return an error.
+	Verify(data, ecc []byte) (bool, error)
+	// Syndromes calculates the syndromes of data followed by ecc into s, S_i
+	// being in s[i]. s must be as long as ecc. It doesn't modify its input nor
+	// allocate memory, except to return an error.
+	Syndromes(data, ecc, s []byte) error
+	// Algorithm returns the algorithm used to solve the key equation.
+	Algorithm() Algorithm
+}
+
+// DecodeResult describes the corrections done by Decoder.DecodeDetailed.
+type DecodeResult struct {
+	// Errors is the number of errors corrected at positions that were not
+	// erasures.
+	Errors int
+	// Erasures is the number of erased bytes that were effectively modified.
+	Erasures int
+	// DataPositions are the indexes of the corrected bytes in data, in
+	// increasing order. The original byte was data[DataPositions[i]] ^
+	// DataMagnitudes[i].
+	DataPositions  []int
+	DataMagnitudes []byte
+	// ECCPositions and ECCMagnitudes are the same for ecc.
+	ECCPositions  []int
+	ECCMagnitudes []byte
+	// Margin is len(ecc)-2*Errors-len(erasures), the correction capability
+	// left. The lower it is, the more likely a miscorrection is.
+	Margin int
+	// Syndromes are the syndromes of the received codeword, S_i being in
+	// Syndromes[i]. They are all zero when there was no error.
+	Syndromes []byte
 }
 
 type rSDecoder struct {
 	f *Field
+	o options
 }
 
 // NewDecoder creates a decoder in the defined field.
-func NewDecoder(f *Field) Decoder {
-	return &rSDecoder{f}
+//
+// The options must match the ones used with NewEncoder.
+func NewDecoder(f *Field, opts ...Option) Decoder {
+	return &rSDecoder{f, getOptions(255, opts)}
+}
+
+func (d *rSDecoder) Algorithm() Algorithm {
+	return d.o.alg
+}
+
+func (d *rSDecoder) Verify(data, ecc []byte) (bool, error) {
+	if err := checkCodewordLen(data, ecc); err != nil {
+		return false, err
+	}
+	for i := range ecc {
+		if d.syndromeAt(data, ecc, i) != 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (d *rSDecoder) Syndromes(data, ecc, s []byte) error {
+	if err := checkCodewordLen(data, ecc); err != nil {
+		return err
+	}
+	if len(s) != len(ecc) {
+		return fmt.Errorf("%w: syndromes buffer has %d bytes, expected %d", ErrInvalidLength, len(s), len(ecc))
+	}
+	d.syndromesInPlace(data, ecc, s)
+	return nil
 }
 
 func (d *rSDecoder) Decode(data, ecc []byte) (int, error) {
+	nbErrors, _, err := d.DecodeErasures(data, ecc, nil)
+	return nbErrors, err
+}
+
+func (d *rSDecoder) DecodeErasures(data, ecc []byte, erasures []int) (int, int, error) {
+	r, err := d.DecodeDetailed(data, ecc, erasures)
+	if err != nil {
+		return 0, 0, err
+	}
+	return r.Errors, r.Erasures, nil
+}
+
+func (d *rSDecoder) DecodeDetailed(data, ecc []byte, erasures []int) (*DecodeResult, error) {
+	if err := checkCodewordLen(data, ecc); err != nil {
+		return nil, err
+	}
 	// TODO(maruel): Temporary migration code.
 	received := make([]byte, len(data)+len(ecc))
 	copy(received, data)
 	copy(received[len(data):], ecc)
-	poly := &poly{d.f, received}
-	syndromeCoeffs := make([]byte, len(ecc))
-	noError := true
-	for i := 0; i < len(ecc); i++ {
-		eval := poly.evaluateAt(d.f.f.Exp(i))
-		syndromeCoeffs[len(syndromeCoeffs)-1-i] = eval
-		if eval != 0 {
-			noError = false
+	if len(erasures) > len(ecc) {
+		return nil, fmt.Errorf("%w: too many erasures: %d > %d", ErrInvalidErasure, len(erasures), len(ecc))
+	}
+	// Build the erasure locator polynomial Γ(x) = Π(1 + Y_i·x).
+	erasureLocations := make([]byte, len(erasures))
+	gamma := getOne(d.f)
+	for i, position := range erasures {
+		if position < 0 || position >= len(received) {
+			return nil, fmt.Errorf("%w: position %d out of range [0, %d)", ErrInvalidErasure, position, len(received))
+		}
+		erasureLocations[i] = d.location(len(received) - 1 - position)
+		for j := 0; j < i; j++ {
+			if erasureLocations[j] == erasureLocations[i] {
+				return nil, fmt.Errorf("%w: duplicate position %d", ErrInvalidErasure, position)
+			}
 		}
+		gamma = gamma.Mul(&Poly{d.f, []byte{erasureLocations[i], 1}})
 	}
-	if noError {
+	result := &DecodeResult{Syndromes: make([]byte, len(ecc))}
+	syndrome := d.syndrome(received, len(ecc))
+	if syndrome == nil {
 		// Congrats! All the data was perfect.
-		return 0, nil
+		return result, nil
+	}
+	for i := range result.Syndromes {
+		result.Syndromes[i] = syndrome.Coefficient(i)
+	}
+	// There was corruption found. Find the locator of the remaining errors.
+	var sigma, omega *Poly
+	var err error
+	switch d.o.alg {
+	case BerlekampMassey:
+		sigma, omega, err = d.runBerlekampMassey(syndrome, gamma, len(ecc), len(erasures))
+	default:
+		// Solve the key equation on the modified syndrome T(x) = S(x)·Γ(x) mod
+		// x^len(ecc).
+		if len(erasures) != 0 {
+			syndrome = syndrome.Mul(gamma).modMonomial(len(ecc))
+		}
+		sigma, omega, err = d.runEuclideanAlgorithm(NewMonomial(d.f, len(ecc), 1), syndrome, len(ecc), len(erasures))
 	}
-	// There was corruption found.
-	syndrome := makePoly(d.f, syndromeCoeffs)
-	sigma, omega, err := d.runEuclideanAlgorithm(buildMonomial(d.f, len(ecc), 1), syndrome, len(ecc))
 	if err != nil {
-		return 0, fmt.Errorf("runEuclidean() over %d bytes + %d ECC bytes failed: %s", len(data), len(ecc), err)
+		return nil, fmt.Errorf("%w: %s over %d bytes + %d ECC bytes failed: %s", ErrKeyEquation, d.o.alg, len(data), len(ecc), err)
 	}
 	errorLocations := d.findErrorLocations(sigma)
 	if errorLocations == nil {
-		return 0, errors.New("error locator degree does not match number of roots")
+		return nil, fmt.Errorf("%w: error locator degree does not match number of roots", ErrBadLocation)
 	}
-	errorMagnitudes := d.findErrorMagnitudes(omega, errorLocations)
+	if 2*len(errorLocations)+len(erasures) > len(ecc) {
+		return nil, fmt.Errorf("%w: %d errors and %d erasures with %d ECC bytes", ErrTooManyErrors, len(errorLocations), len(erasures), len(ecc))
+	}
+	if len(errorLocations)+len(erasures) == 0 {
+		return nil, fmt.Errorf("%w: no error found while the syndrome is not zero", ErrBadLocation)
+	}
+	// Past the correction capability, Euclid's algorithm can return an
+	// evaluator that doesn't match the errata locator σ(x)·Γ(x).
+	if l := sigma.Degree() + len(erasures); omega.Degree() >= l {
+		return nil, fmt.Errorf("%w: errata evaluator degree %d is not lower than the errata locator degree %d", ErrKeyEquation, omega.Degree(), l)
+	}
+	// Omega is the errata evaluator; the errata locator is Λ(x)·Γ(x) and its
+	// roots are both the error and erasure locations.
+	errataLocations := append(errorLocations, erasureLocations...)
+	errataMagnitudes := d.findErrorMagnitudes(omega, errataLocations)
+	positions := make([]int, len(errataLocations))
 	for i := 0; i < len(errorLocations); i++ {
-		position := len(received) - 1 - d.f.f.Log(errorLocations[i])
+		position := len(received) - 1 - d.f.f.Log(errorLocations[i])*d.o.iprim%255
 		if position < 0 {
-			return 0, fmt.Errorf("bad error location: %d", position)
+			return nil, fmt.Errorf("%w: error location %d is outside of the codeword", ErrMiscorrection, position)
+		}
+		for _, e := range erasures {
+			if e == position {
+				return nil, fmt.Errorf("%w: error location %d is also an erasure", ErrBadLocation, position)
+			}
+		}
+		positions[i] = position
+	}
+	copy(positions[len(errorLocations):], erasures)
+	result.Errors = len(errorLocations)
+	result.Margin = len(ecc) - 2*len(errorLocations) - len(erasures)
+	magnitudes := make([]byte, len(received))
+	for i, position := range positions {
+		if i >= len(errorLocations) && errataMagnitudes[i] != 0 {
+			result.Erasures++
+		}
+		magnitudes[position] = errataMagnitudes[i]
+	}
+	for position, m := range magnitudes {
+		if m == 0 {
+			continue
 		}
 		// Calculate the original value.
-		received[position] = d.f.f.Add(received[position], errorMagnitudes[i])
+		received[position] = d.f.f.Add(received[position], m)
+		if position < len(data) {
+			result.DataPositions = append(result.DataPositions, position)
+			result.DataMagnitudes = append(result.DataMagnitudes, m)
+		} else {
+			result.ECCPositions = append(result.ECCPositions, position-len(data))
+			result.ECCMagnitudes = append(result.ECCMagnitudes, m)
+		}
+	}
+	if d.o.recheck && d.syndrome(received, len(ecc)) != nil {
+		return nil, ErrMiscorrection
 	}
 	// Copy back.
 	// TODO(maruel): Work in-place instead.
 	copy(data, received)
 	copy(ecc, received[len(data):])
-	return len(errorLocations), nil
+	return result, nil
+}
+
+// checkCodewordLen returns an error if data and ecc don't fit in a codeword.
+func checkCodewordLen(data, ecc []byte) error {
+	if len(data)+len(ecc) > 255 {
+		return fmt.Errorf("%w: codeword of %d bytes + %d ECC bytes is longer than 255 bytes", ErrInvalidLength, len(data), len(ecc))
+	}
+	return nil
+}
+
+// location returns the error location of the term of x^power, which is
+// α^(prim*power).
+func (d *rSDecoder) location(power int) byte {
+	return d.f.f.Exp(d.o.prim * power % 255)
 }
 
-func (d *rSDecoder) runEuclideanAlgorithm(a, b *poly, R int) (sigma *poly, omega *poly, err error) {
+// syndrome returns the syndrome polynomial of received, or nil if all the
+// syndromes are zero, e.g. there is no error.
+func (d *rSDecoder) syndrome(received []byte, nbECC int) *Poly {
+	p := &Poly{d.f, received}
+	syndromeCoeffs := make([]byte, nbECC)
+	noError := true
+	for i := 0; i < nbECC; i++ {
+		eval := p.Evaluate(d.location(d.o.fcr + i))
+		syndromeCoeffs[len(syndromeCoeffs)-1-i] = eval
+		if eval != 0 {
+			noError = false
+		}
+	}
+	if noError {
+		return nil
+	}
+	return makePoly(d.f, syndromeCoeffs)
+}
+
+// runEuclideanAlgorithm solves the key equation. With nbErasures erasures, b
+// must be the modified syndrome and sigma is the locator of the errors only.
+func (d *rSDecoder) runEuclideanAlgorithm(a, b *Poly, R, nbErasures int) (sigma *Poly, omega *Poly, err error) {
 	// Assume a's degree >= b's
-	if a.degree() < b.degree() {
+	if a.Degree() < b.Degree() {
 		a, b = b, a
 	}
 	rLast := a
@@ -117,51 +322,117 @@ func (d *rSDecoder) runEuclideanAlgorithm(a, b *poly, R int) (sigma *poly, omega
 	tLast := getZero(d.f)
 	t := getOne(d.f)
 
-	// Run Euclidean algorithm until r's degree is less than R/2.
-	for i := 0; r.degree() >= R/2; i++ {
+	// Run Euclidean algorithm until r's degree is less than (R+nbErasures)/2.
+	for i := 0; r.Degree() >= (R+nbErasures)/2; i++ {
 		rLastLast := rLast
 		tLastLast := tLast
 		rLast = r
 		tLast = t
 
 		// Divide rLastLast by rLast, with quotient in q and remainder in r.
-		if rLast.isZero() {
+		if rLast.IsZero() {
 			// Oops, Euclidean algorithm already terminated?
 			return nil, nil, fmt.Errorf("r_{i-1} was zero after %d iteration(s)", i)
 		}
 		r = rLastLast
 		q := getZero(d.f)
-		dltInverse := d.f.f.Inv(rLast.getCoefficient(rLast.degree()))
-		for r.degree() >= rLast.degree() && !r.isZero() {
+		dltInverse := d.f.f.Inv(rLast.Coefficient(rLast.Degree()))
+		for r.Degree() >= rLast.Degree() && !r.IsZero() {
 			// degreDiff is guaranteed to be >= 0
-			degreeDiff := r.degree() - rLast.degree()
-			scale := d.f.f.Mul(r.getCoefficient(r.degree()), dltInverse)
-			q = q.add(buildMonomial(d.f, degreeDiff, scale))
-			r = r.add(rLast.mulByMonomial(degreeDiff, scale))
+			degreeDiff := r.Degree() - rLast.Degree()
+			scale := d.f.f.Mul(r.Coefficient(r.Degree()), dltInverse)
+			q = q.Add(NewMonomial(d.f, degreeDiff, scale))
+			r = r.Add(rLast.MulMonomial(degreeDiff, scale))
 		}
-		t = q.mulPoly(tLast).add(tLastLast)
+		t = q.Mul(tLast).Add(tLastLast)
 	}
 
-	sigmaTildeAtZero := t.getCoefficient(0)
+	sigmaTildeAtZero := t.Coefficient(0)
 	if sigmaTildeAtZero == 0 {
 		return nil, nil, errors.New("sigmaTilde(0) was zero")
 	}
 
 	inverse := d.f.f.Inv(sigmaTildeAtZero)
-	return t.mulScalar(inverse), r.mulScalar(inverse), nil
+	return t.MulScalar(inverse), r.MulScalar(inverse), nil
+}
+
+// runBerlekampMassey solves the key equation with the Berlekamp-Massey
+// algorithm. It returns the same values as runEuclideanAlgorithm, except that
+// syndrome is the unmodified syndrome and gamma the erasure locator.
+func (d *rSDecoder) runBerlekampMassey(syndrome, gamma *Poly, R, nbErasures int) (sigma *Poly, omega *Poly, err error) {
+	s := make([]byte, R)
+	for i := range s {
+		s[i] = syndrome.Coefficient(i)
+	}
+	lambda := make([]byte, R+1)
+	for i := 0; i <= gamma.Degree(); i++ {
+		lambda[i] = gamma.Coefficient(i)
+	}
+	l := berlekampMassey(d.f, s, lambda, make([]byte, R+1), make([]byte, R+1), nbErasures)
+	// Convert the errata locator to a polynomial.
+	coefficients := make([]byte, l+1)
+	for i := range coefficients {
+		coefficients[i] = lambda[l-i]
+	}
+	psi := makePoly(d.f, coefficients)
+	if psi.Degree() != l {
+		return nil, nil, fmt.Errorf("errata locator degree %d does not match its length %d", psi.Degree(), l)
+	}
+	// Remove the erasures from the errata locator.
+	sigma, r := psi.Divide(gamma)
+	if !r.IsZero() {
+		return nil, nil, errors.New("errata locator is not a multiple of the erasure locator")
+	}
+	return sigma, syndrome.Mul(psi).modMonomial(R), nil
+}
+
+// berlekampMassey calculates the errata locator Ψ(x) in lambda, stored from
+// the constant term up, from the syndromes s. lambda must be initialized with
+// the erasure locator and b and t are scratch buffers as long as lambda.
+//
+// Returns the length of the LFSR, which is the expected degree of Ψ(x).
+func berlekampMassey(f *Field, s, lambda, b, t []byte, nbErasures int) int {
+	copy(b, lambda)
+	l := nbErasures
+	for r := nbErasures; r < len(s); r++ {
+		// Discrepancy, the coefficient of x^r in Ψ(x)·S(x).
+		delta := byte(0)
+		for j := 0; j <= r; j++ {
+			delta ^= f.f.Mul(lambda[j], s[r-j])
+		}
+		// b = x·b
+		copy(b[1:], b[:len(b)-1])
+		b[0] = 0
+		if delta == 0 {
+			continue
+		}
+		// t = lambda + delta·b
+		for j := range t {
+			t[j] = lambda[j] ^ f.f.Mul(delta, b[j])
+		}
+		if 2*l <= r+nbErasures {
+			l = r + 1 + nbErasures - l
+			inv := f.f.Inv(delta)
+			for j := range b {
+				b[j] = f.f.Mul(inv, lambda[j])
+			}
+		}
+		copy(lambda, t)
+	}
+	return l
 }
 
 // This is a direct application of Chien's search.
-func (d *rSDecoder) findErrorLocations(errorLocator *poly) []byte {
-	numErrors := errorLocator.degree()
+func (d *rSDecoder) findErrorLocations(errorLocator *Poly) []byte {
+	numErrors := errorLocator.Degree()
 	if numErrors == 1 {
 		// Shortcut.
-		return []byte{errorLocator.getCoefficient(1)}
+		return []byte{errorLocator.Coefficient(1)}
 	}
 	result := make([]byte, numErrors)
 	e := 0
 	for i := 1; i < 256 && e < numErrors; i++ {
-		if errorLocator.evaluateAt(byte(i)) == 0 {
+		if errorLocator.Evaluate(byte(i)) == 0 {
 			result[e] = d.f.f.Inv(byte(i))
 			e++
 		}
@@ -173,7 +444,7 @@ func (d *rSDecoder) findErrorLocations(errorLocator *poly) []byte {
 }
 
 // This is directly applying Forney's Formula.
-func (d *rSDecoder) findErrorMagnitudes(errorEvaluator *poly, errorLocations []byte) []byte {
+func (d *rSDecoder) findErrorMagnitudes(errorEvaluator *Poly, errorLocations []byte) []byte {
 	s := len(errorLocations)
 	result := make([]byte, s)
 	for i := 0; i < s; i++ {
@@ -184,7 +455,11 @@ func (d *rSDecoder) findErrorMagnitudes(errorEvaluator *poly, errorLocations []b
 				denominator = d.f.f.Mul(denominator, d.f.f.Add(1, d.f.f.Mul(errorLocations[j], xiInverse)))
 			}
 		}
-		result[i] = d.f.f.Mul(errorEvaluator.evaluateAt(xiInverse), d.f.f.Inv(denominator))
+		result[i] = d.f.f.Mul(errorEvaluator.Evaluate(xiInverse), d.f.f.Inv(denominator))
+		if d.o.fcr != 0 {
+			// The syndromes start at X^fcr instead of X^0.
+			result[i] = d.f.f.Mul(result[i], d.f.f.Exp(d.f.f.Log(xiInverse)*d.o.fcr%255))
+		}
 	}
 	return result
 }
@@ -26,6 +26,8 @@ limitations under the License. */
 package rs
 
 import (
+	"bytes"
+	"errors"
 	"math/rand"
 	"testing"
 
@@ -96,27 +98,420 @@ func TestMaxErrors(t *testing.T) {
 func TestTooManyErrors(t *testing.T) {
 	complete := getcomplete()
 	corrupt(complete, QRCodeCorrectable+1)
+	for _, alg := range algorithms {
+		d := NewDecoder(QRCodeField256, UseAlgorithm(alg))
+		c := makecopy(complete)
+		if nb, err := d.Decode(c[:len(QRCodeTestData)], c[len(QRCodeTestData):]); err == nil {
+			t.Fatalf("%s: Recovered unrecoverable error!?!", alg)
+		} else if nb != 0 {
+			t.Fatalf("%s: Err != %d", alg, nb)
+		}
+	}
+}
+
+func TestErasuresNoError(t *testing.T) {
+	data := makecopy(QRCodeTestData)
+	ecc := makecopy(QRCodeTestECC)
+	checkQRErasures(t, data, ecc, []int{0, 3, 20}, 0, 0)
+}
+
+func TestErasuresMax(t *testing.T) {
+	for i := 0; i+len(QRCodeTestECC) <= len(QRCodeTestData)+len(QRCodeTestECC); i++ {
+		complete := getcomplete()
+		erasures := make([]int, len(QRCodeTestECC))
+		for j := range erasures {
+			erasures[j] = i + j
+			complete[i+j] = 0
+		}
+		checkQRErasures(t, complete[:len(QRCodeTestData)], complete[len(QRCodeTestData):], erasures, 0, -1)
+	}
+}
+
+func TestErasuresRandom(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		complete := getcomplete()
+		erasures := rand.Perm(len(complete))[:1+rand.Intn(len(QRCodeTestECC))]
+		nb := 0
+		for _, p := range erasures {
+			if v := byte(rand.Intn(256)); v != complete[p] {
+				complete[p] = v
+				nb++
+			}
+		}
+		checkQRErasures(t, complete[:len(QRCodeTestData)], complete[len(QRCodeTestData):], erasures, 0, nb)
+	}
+}
+
+func TestErrorsAndErasures(t *testing.T) {
+	// Try all the combinations that satisfy 2*errors+erasures <= len(ecc).
+	for nbErasures := 0; nbErasures <= len(QRCodeTestECC); nbErasures++ {
+		for nbErrors := 0; 2*nbErrors+nbErasures <= len(QRCodeTestECC); nbErrors++ {
+			for i := 0; i < 20; i++ {
+				complete := getcomplete()
+				perm := rand.Perm(len(complete))
+				erasures := perm[:nbErasures]
+				for _, p := range erasures {
+					complete[p] = 0
+				}
+				for _, p := range perm[nbErasures : nbErasures+nbErrors] {
+					complete[p] ^= byte(1 + rand.Intn(255))
+				}
+				checkQRErasures(t, complete[:len(QRCodeTestData)], complete[len(QRCodeTestData):], erasures, nbErrors, -1)
+			}
+		}
+	}
+}
+
+func TestErrorsAndErasuresTooMany(t *testing.T) {
+	d := NewDecoder(QRCodeField256)
+	complete := getcomplete()
+	// 2*3+5 > 10.
+	erasures := []int{0, 1, 2, 3, 4}
+	for _, p := range erasures {
+		complete[p] ^= 0xFF
+	}
+	complete[10] ^= 1
+	complete[12] ^= 2
+	complete[20] ^= 3
+	nbErrors, nbErasures, err := d.DecodeErasures(complete[:len(QRCodeTestData)], complete[len(QRCodeTestData):], erasures)
+	if err == nil {
+		if bytes.Equal(complete, getcomplete()) {
+			t.Fatal("Recovered unrecoverable error!?!")
+		}
+	} else if nbErrors != 0 || nbErasures != 0 {
+		t.Fatalf("Err != %d, %d", nbErrors, nbErasures)
+	}
+}
+
+func TestErasuresInvalid(t *testing.T) {
+	d := NewDecoder(QRCodeField256)
+	positions := []int{-1, len(QRCodeTestData) + len(QRCodeTestECC)}
+	for _, p := range positions {
+		if _, _, err := d.DecodeErasures(makecopy(QRCodeTestData), makecopy(QRCodeTestECC), []int{p}); err == nil {
+			t.Fatalf("position %d: expected error", p)
+		}
+	}
+	if _, _, err := d.DecodeErasures(makecopy(QRCodeTestData), makecopy(QRCodeTestECC), []int{2, 2}); err == nil {
+		t.Fatal("expected error")
+	}
+	tooMany := make([]int, len(QRCodeTestECC)+1)
+	for i := range tooMany {
+		tooMany[i] = i
+	}
+	if _, _, err := d.DecodeErasures(makecopy(QRCodeTestData), makecopy(QRCodeTestECC), tooMany); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestDecodeDetailed(t *testing.T) {
+	for _, alg := range algorithms {
+		d := NewDecoder(QRCodeField256, UseAlgorithm(alg))
+		r, err := d.DecodeDetailed(makecopy(QRCodeTestData), makecopy(QRCodeTestECC), nil)
+		if err != nil || r.Errors != 0 || r.Erasures != 0 || r.DataPositions != nil || r.ECCPositions != nil {
+			t.Fatalf("%s: %#v, %v", alg, r, err)
+		}
+		compare(t, r.Syndromes, make([]byte, len(QRCodeTestECC)), "Syndromes are not zero")
+
+		// 2 errors, 1 erasure with an error and 1 erasure without error.
+		data := makecopy(QRCodeTestData)
+		ecc := makecopy(QRCodeTestECC)
+		data[9] ^= 0x42
+		data[3] ^= 0x01
+		ecc[4] ^= 0xFF
+		r, err = d.DecodeDetailed(data, ecc, []int{len(data) + 4, 0})
+		if err != nil {
+			t.Fatalf("%s: %v", alg, err)
+		}
+		if r.Errors != 2 || r.Erasures != 1 || r.Margin != 4 {
+			t.Fatalf("%s: %d errors, %d erasures, margin %d", alg, r.Errors, r.Erasures, r.Margin)
+		}
+		if len(r.DataPositions) != 2 || r.DataPositions[0] != 3 || r.DataPositions[1] != 9 {
+			t.Fatalf("%s: %v", alg, r.DataPositions)
+		}
+		compare(t, r.DataMagnitudes, []byte{0x01, 0x42}, "Data magnitudes differ")
+		if len(r.ECCPositions) != 1 || r.ECCPositions[0] != 4 {
+			t.Fatalf("%s: %v", alg, r.ECCPositions)
+		}
+		compare(t, r.ECCMagnitudes, []byte{0xFF}, "ECC magnitudes differ")
+		if len(r.Syndromes) != len(QRCodeTestECC) || bytes.Equal(r.Syndromes, make([]byte, len(QRCodeTestECC))) {
+			t.Fatalf("%s: %v", alg, r.Syndromes)
+		}
+		compare(t, data, QRCodeTestData, "Data differs")
+		compare(t, ecc, QRCodeTestECC, "ECC differs")
+	}
+}
+
+func TestVerify(t *testing.T) {
 	d := NewDecoder(QRCodeField256)
-	if nb, err := d.Decode(complete[:len(QRCodeTestData)], complete[len(QRCodeTestData):]); err == nil {
-		t.Fatal("Recovered unrecoverable error!?!")
-	} else if nb != 0 {
-		t.Fatalf("Err != %d", nb)
+	if ok, err := d.Verify(QRCodeTestData, QRCodeTestECC); !ok || err != nil {
+		t.Fatal(ok, err)
+	}
+	for i := 0; i < 100; i++ {
+		complete := getcomplete()
+		corrupt(complete, 1+rand.Intn(len(complete)))
+		c := makecopy(complete)
+		if ok, err := d.Verify(c[:len(QRCodeTestData)], c[len(QRCodeTestData):]); ok || err != nil {
+			t.Fatal(ok, err)
+		}
+		compare(t, c, complete, "Verify modified its input")
+	}
+	if _, err := d.Verify(make([]byte, 250), make([]byte, 10)); err == nil {
+		t.Fatal("expected error")
+	}
+	allocs := testing.AllocsPerRun(100, func() {
+		d.Verify(QRCodeTestData, QRCodeTestECC)
+	})
+	if allocs != 0 {
+		t.Fatalf("%g allocations", allocs)
 	}
 }
 
+func TestSyndromes(t *testing.T) {
+	d := NewDecoder(QRCodeField256, FirstRoot(1))
+	data := makecopy(QRCodeTestData)
+	ecc := make([]byte, len(QRCodeTestECC))
+	NewEncoder(QRCodeField256, len(ecc), FirstRoot(1)).Encode(data, ecc)
+	s := make([]byte, len(ecc))
+	if err := d.Syndromes(data, ecc, s); err != nil {
+		t.Fatal(err)
+	}
+	compare(t, s, make([]byte, len(ecc)), "Syndromes are not zero")
+	data[2] ^= 0x10
+	ecc[7] ^= 0x20
+	if err := d.Syndromes(data, ecc, s); err != nil {
+		t.Fatal(err)
+	}
+	r, err := d.DecodeDetailed(makecopy(data), makecopy(ecc), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	compare(t, s, r.Syndromes, "Syndromes differ")
+	if err := d.Syndromes(data, ecc, s[:1]); err == nil {
+		t.Fatal("expected error")
+	}
+	allocs := testing.AllocsPerRun(100, func() {
+		d.Syndromes(data, ecc, s)
+	})
+	if allocs != 0 {
+		t.Fatalf("%g allocations", allocs)
+	}
+}
+
+func TestMiscorrection(t *testing.T) {
+	w := &Workspace{}
+	wd := NewWideDecoder(NewWideField(0x11D, 2), CheckMiscorrection(true))
+	found := 0
+	foundWith := 0
+	foundWide := 0
+	for _, alg := range algorithms {
+		d := NewDecoder(QRCodeField256, UseAlgorithm(alg), CheckMiscorrection(true))
+		for i := 0; i < 1000; i++ {
+			complete := getcomplete()
+			corrupt(complete, QRCodeCorrectable+1+rand.Intn(4))
+			c := makecopy(complete)
+			r, err := d.DecodeDetailed(c[:len(QRCodeTestData)], c[len(QRCodeTestData):], nil)
+			if errors.Is(err, ErrMiscorrection) {
+				found++
+				compare(t, c, complete, "Input was modified")
+			} else if err == nil {
+				if r.Margin < 0 {
+					t.Fatalf("%s: margin %d", alg, r.Margin)
+				}
+				if ok, _ := d.Verify(c[:len(QRCodeTestData)], c[len(QRCodeTestData):]); !ok {
+					t.Fatalf("%s: not a codeword", alg)
+				}
+			}
+			// The decoders using the same algorithm report the same
+			// miscorrections. DecodeWith uses Berlekamp-Massey and WideDecoder
+			// uses Euclid's algorithm.
+			miscorrected := errors.Is(err, ErrMiscorrection)
+			c = makecopy(complete)
+			if _, _, err = d.DecodeWith(w, c[:len(QRCodeTestData)], c[len(QRCodeTestData):], nil); errors.Is(err, ErrMiscorrection) {
+				foundWith++
+				compare(t, c, complete, "Input was modified")
+			} else if miscorrected && alg == BerlekampMassey {
+				t.Fatalf("%s: DecodeWith: expected miscorrection, got %v", alg, err)
+			} else if err == nil {
+				if ok, _ := d.Verify(c[:len(QRCodeTestData)], c[len(QRCodeTestData):]); !ok {
+					t.Fatalf("%s: not a codeword", alg)
+				}
+			}
+			wc := make([]uint16, len(complete))
+			for j, v := range complete {
+				wc[j] = uint16(v)
+			}
+			if _, err = wd.Decode(wc[:len(QRCodeTestData)], wc[len(QRCodeTestData):]); errors.Is(err, ErrMiscorrection) {
+				foundWide++
+			} else if miscorrected && alg == Euclidean {
+				t.Fatalf("%s: WideDecoder: expected miscorrection, got %v", alg, err)
+			}
+		}
+	}
+	if found == 0 || foundWith == 0 || foundWide == 0 {
+		t.Fatalf("expected miscorrections outside of the shortened codeword: %d, %d, %d", found, foundWith, foundWide)
+	}
+}
+
+// Past the correction capability, Euclid's algorithm found an evaluator
+// that was not compatible with the errata locator and the result was not a
+// codeword.
+func TestDecodeErasuresEuclideanBeyondCapability(t *testing.T) {
+	complete := []byte{
+		0x10, 0x4d, 0xa0, 0x7f, 0x61, 0x2a, 0x0e, 0x11, 0xec, 0xc2, 0xec, 0x11, 0xec,
+		0x11, 0xec, 0xdd, 0xcd, 0x24, 0xd4, 0xc1, 0xed, 0x98, 0xc7, 0x87, 0x2c, 0x55,
+	}
+	erasures := []int{2, 16, 3, 21, 1, 6}
+	d := NewDecoder(QRCodeField256, UseAlgorithm(Euclidean))
+	c := makecopy(complete)
+	if _, _, err := d.DecodeErasures(c[:len(QRCodeTestData)], c[len(QRCodeTestData):], erasures); !errors.Is(err, ErrKeyEquation) {
+		t.Fatal(err)
+	}
+	compare(t, complete, c, "Input was modified")
+	wc := make([]uint16, len(complete))
+	for i, v := range complete {
+		wc[i] = uint16(v)
+	}
+	wd := NewWideDecoder(NewWideField(0x11D, 2))
+	if _, _, err := wd.DecodeErasures(wc[:len(QRCodeTestData)], wc[len(QRCodeTestData):], erasures); !errors.Is(err, ErrKeyEquation) {
+		t.Fatal(err)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	d := NewDecoder(QRCodeField256)
+	if _, _, err := d.DecodeErasures(makecopy(QRCodeTestData), makecopy(QRCodeTestECC), []int{3, 3}); !errors.Is(err, ErrInvalidErasure) {
+		t.Fatal(err)
+	}
+	if _, err := d.Verify(make([]byte, 250), make([]byte, 10)); !errors.Is(err, ErrInvalidLength) {
+		t.Fatal(err)
+	}
+	// Too long for a codeword, the positions would alias modulo 255.
+	data := make([]byte, 290)
+	data[3] = 1
+	data[258] = 1
+	ecc := make([]byte, 10)
+	if _, err := d.Decode(data, ecc); !errors.Is(err, ErrInvalidLength) {
+		t.Fatal(err)
+	}
+	if _, _, err := d.DecodeErasures(data, ecc, nil); !errors.Is(err, ErrInvalidLength) {
+		t.Fatal(err)
+	}
+	if _, err := d.DecodeDetailed(data, ecc, nil); !errors.Is(err, ErrInvalidLength) {
+		t.Fatal(err)
+	}
+	if _, _, err := d.DecodeWith(&Workspace{}, data, ecc, nil); !errors.Is(err, ErrInvalidLength) {
+		t.Fatal(err)
+	}
+	if data[3] != 1 || data[258] != 1 {
+		t.Fatal("input was modified")
+	}
+	for i := 0; i < 1000; i++ {
+		complete := getcomplete()
+		corrupt(complete, QRCodeCorrectable+1+rand.Intn(8))
+		_, err := d.Decode(complete[:len(QRCodeTestData)], complete[len(QRCodeTestData):])
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, ErrTooManyErrors) && !errors.Is(err, ErrKeyEquation) && !errors.Is(err, ErrBadLocation) && !errors.Is(err, ErrMiscorrection) {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
+
+func TestDecodeRoots(t *testing.T) {
+	data := []struct{ fcr, prim int }{{1, 1}, {112, 11}, {-1, 2}, {0, 254}}
+	for i := 0; i < len(data)*len(algorithms); i++ {
+		line := data[i/len(algorithms)]
+		opts := []Option{FirstRoot(line.fcr), RootSpacing(line.prim), UseAlgorithm(algorithms[i%len(algorithms)])}
+		golden := makecopy(Rand128[:100])
+		goldenECC := make([]byte, 16)
+		NewEncoder(QRCodeField256, len(goldenECC), opts...).Encode(golden, goldenECC)
+		d := NewDecoder(QRCodeField256, opts...)
+		for nbErasures := 0; nbErasures <= len(goldenECC); nbErasures += 3 {
+			nbErrors := (len(goldenECC) - nbErasures) / 2
+			complete := append(makecopy(golden), goldenECC...)
+			perm := rand.Perm(len(complete))
+			for _, p := range perm[:nbErasures+nbErrors] {
+				complete[p] ^= byte(1 + rand.Intn(255))
+			}
+			data, ecc := complete[:len(golden)], complete[len(golden):]
+			n, m, err := d.DecodeErasures(data, ecc, perm[:nbErasures])
+			if err != nil || n != nbErrors || m != nbErasures {
+				t.Fatalf("fcr=%d, prim=%d: expected %d errors, %d erasures; got %d, %d: %v", line.fcr, line.prim, nbErrors, nbErasures, n, m, err)
+			}
+			compare(t, data, golden, "Data differs")
+			compare(t, ecc, goldenECC, "ECC differs")
+		}
+	}
+}
+
+func TestAlgorithm(t *testing.T) {
+	if s := Euclidean.String(); s != "Euclidean" {
+		t.Fatal(s)
+	}
+	if s := BerlekampMassey.String(); s != "BerlekampMassey" {
+		t.Fatal(s)
+	}
+	if s := Algorithm(42).String(); s != "Algorithm(42)" {
+		t.Fatal(s)
+	}
+	if a := NewDecoder(QRCodeField256).Algorithm(); a != Euclidean {
+		t.Fatal(a)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic")
+		}
+	}()
+	NewDecoder(QRCodeField256, UseAlgorithm(Algorithm(42)))
+}
+
+// algorithms are all the supported algorithms, to test all of them.
+var algorithms = []Algorithm{Euclidean, BerlekampMassey}
+
+// checkQR verifies that the errors are recovered with all the algorithms.
 func checkQR(t *testing.T, data, ecc []byte, nbErrors int) {
 	goldenData := QRCodeTestData
 	goldenEcc := QRCodeTestECC
-	d := NewDecoder(QRCodeField256)
-	errorsFound, err := d.Decode(data, ecc)
-	if err != nil {
-		t.Fatalf("Got error: %s", err)
+	for _, alg := range algorithms {
+		d := NewDecoder(QRCodeField256, UseAlgorithm(alg))
+		if d.Algorithm() != alg {
+			t.Fatalf("%s != %s", d.Algorithm(), alg)
+		}
+		data := makecopy(data)
+		ecc := makecopy(ecc)
+		errorsFound, err := d.Decode(data, ecc)
+		if err != nil {
+			t.Fatalf("%s: Got error: %s", alg, err)
+		}
+		if nbErrors != errorsFound {
+			t.Fatalf("%s: Expected %d errors, got %d", alg, nbErrors, errorsFound)
+		}
+		compare(t, data, goldenData, "Data differs")
+		compare(t, ecc, goldenEcc, "ECC differs")
 	}
-	if nbErrors != errorsFound {
-		t.Fatalf("Expected %d errors, got %d", nbErrors, errorsFound)
+}
+
+// checkQRErasures verifies that errors and erasures are recovered. A negative
+// nbErasures skips the check of the number of erased bytes fixed.
+func checkQRErasures(t *testing.T, data, ecc []byte, erasures []int, nbErrors, nbErasures int) {
+	for _, alg := range algorithms {
+		d := NewDecoder(QRCodeField256, UseAlgorithm(alg))
+		data := makecopy(data)
+		ecc := makecopy(ecc)
+		errorsFound, erasuresFound, err := d.DecodeErasures(data, ecc, erasures)
+		if err != nil {
+			t.Fatalf("%s: Got error: %s", alg, err)
+		}
+		if nbErrors != errorsFound {
+			t.Fatalf("%s: Expected %d errors, got %d", alg, nbErrors, errorsFound)
+		}
+		if nbErasures >= 0 && nbErasures != erasuresFound {
+			t.Fatalf("%s: Expected %d erasures, got %d", alg, nbErasures, erasuresFound)
+		}
+		compare(t, data, QRCodeTestData, "Data differs")
+		compare(t, ecc, QRCodeTestECC, "ECC differs")
 	}
-	compare(t, data, goldenData, "Data differs")
-	compare(t, ecc, goldenEcc, "ECC differs")
 }
 
 func corrupt(received []byte, howMany int) {
@@ -133,81 +528,126 @@ func corrupt(received []byte, howMany int) {
 }
 
 func BenchmarkDecode16_10(b *testing.B) {
-	b.StopTimer()
-	d := NewDecoder(QRCodeField256)
-	data := makecopy(QRCodeTestData)
-	ecc := makecopy(QRCodeTestECC)
-	b.SetBytes(int64(len(data) * b.N))
-	b.StartTimer()
-	for i := 0; i < b.N; i++ {
-		if n, err := d.Decode(data, ecc); err != nil || n != 0 {
-			b.Fail()
-		}
+	for _, alg := range algorithms {
+		b.Run(alg.String(), func(b *testing.B) {
+			b.StopTimer()
+			d := NewDecoder(QRCodeField256, UseAlgorithm(alg))
+			data := makecopy(QRCodeTestData)
+			ecc := makecopy(QRCodeTestECC)
+			b.SetBytes(int64(len(data) * b.N))
+			b.StartTimer()
+			for i := 0; i < b.N; i++ {
+				if n, err := d.Decode(data, ecc); err != nil || n != 0 {
+					b.Fail()
+				}
+			}
+		})
 	}
 }
 
 func BenchmarkDecode16_10With1Error(b *testing.B) {
-	b.StopTimer()
-	d := NewDecoder(QRCodeField256)
-	data := makecopy(QRCodeTestData)
-	data[1] = data[1] + 1
-	ecc := makecopy(QRCodeTestECC)
-	b.SetBytes(int64(len(data) * b.N))
-	b.StartTimer()
-	for i := 0; i < b.N; i++ {
-		if n, err := d.Decode(makecopy(data), ecc); err != nil || n != 1 {
-			b.Fail()
-		}
+	for _, alg := range algorithms {
+		b.Run(alg.String(), func(b *testing.B) {
+			b.StopTimer()
+			d := NewDecoder(QRCodeField256, UseAlgorithm(alg))
+			data := makecopy(QRCodeTestData)
+			data[1] = data[1] + 1
+			ecc := makecopy(QRCodeTestECC)
+			b.SetBytes(int64(len(data) * b.N))
+			b.StartTimer()
+			for i := 0; i < b.N; i++ {
+				if n, err := d.Decode(makecopy(data), ecc); err != nil || n != 1 {
+					b.Fail()
+				}
+			}
+		})
 	}
 }
 
 func BenchmarkDecode128_16(b *testing.B) {
-	b.StopTimer()
-	data := makecopy(Rand128)
-	ecc := make([]byte, 16)
-	e := NewEncoder(QRCodeField256, len(ecc))
-	e.Encode(data, ecc)
-	d := NewDecoder(QRCodeField256)
-	b.SetBytes(int64(len(data) * b.N))
-	b.StartTimer()
-	for i := 0; i < b.N; i++ {
-		if n, err := d.Decode(data, ecc); err != nil || n != 0 {
-			b.Fail()
-		}
+	for _, alg := range algorithms {
+		b.Run(alg.String(), func(b *testing.B) {
+			b.StopTimer()
+			data := makecopy(Rand128)
+			ecc := make([]byte, 16)
+			e := NewEncoder(QRCodeField256, len(ecc))
+			e.Encode(data, ecc)
+			d := NewDecoder(QRCodeField256, UseAlgorithm(alg))
+			b.SetBytes(int64(len(data) * b.N))
+			b.StartTimer()
+			for i := 0; i < b.N; i++ {
+				if n, err := d.Decode(data, ecc); err != nil || n != 0 {
+					b.Fail()
+				}
+			}
+		})
 	}
 }
 
 func BenchmarkDecode128_16With1Error(b *testing.B) {
-	b.StopTimer()
-	data := makecopy(Rand128)
-	ecc := make([]byte, 16)
-	e := NewEncoder(QRCodeField256, len(ecc))
-	e.Encode(data, ecc)
-	d := NewDecoder(QRCodeField256)
-	data[1] = data[1] + 1
-	b.SetBytes(int64(len(data) * b.N))
-	b.StartTimer()
-	for i := 0; i < b.N; i++ {
-		if n, err := d.Decode(makecopy(data), ecc); err != nil || n != 1 {
-			b.Fail()
-		}
+	for _, alg := range algorithms {
+		b.Run(alg.String(), func(b *testing.B) {
+			b.StopTimer()
+			data := makecopy(Rand128)
+			ecc := make([]byte, 16)
+			e := NewEncoder(QRCodeField256, len(ecc))
+			e.Encode(data, ecc)
+			d := NewDecoder(QRCodeField256, UseAlgorithm(alg))
+			data[1] = data[1] + 1
+			b.SetBytes(int64(len(data) * b.N))
+			b.StartTimer()
+			for i := 0; i < b.N; i++ {
+				if n, err := d.Decode(makecopy(data), ecc); err != nil || n != 1 {
+					b.Fail()
+				}
+			}
+		})
 	}
 }
 
 func BenchmarkDecode128_16With2Error(b *testing.B) {
-	b.StopTimer()
-	data := makecopy(Rand128)
-	ecc := make([]byte, 16)
-	e := NewEncoder(QRCodeField256, len(ecc))
-	e.Encode(data, ecc)
-	d := NewDecoder(QRCodeField256)
-	data[1] = data[1] + 1
-	ecc[1] = ecc[1] + 1
-	b.SetBytes(int64(len(data) * b.N))
-	b.StartTimer()
-	for i := 0; i < b.N; i++ {
-		if n, err := d.Decode(makecopy(data), makecopy(ecc)); err != nil || n != 2 {
-			b.Fail()
-		}
+	for _, alg := range algorithms {
+		b.Run(alg.String(), func(b *testing.B) {
+			b.StopTimer()
+			data := makecopy(Rand128)
+			ecc := make([]byte, 16)
+			e := NewEncoder(QRCodeField256, len(ecc))
+			e.Encode(data, ecc)
+			d := NewDecoder(QRCodeField256, UseAlgorithm(alg))
+			data[1] = data[1] + 1
+			ecc[1] = ecc[1] + 1
+			b.SetBytes(int64(len(data) * b.N))
+			b.StartTimer()
+			for i := 0; i < b.N; i++ {
+				if n, err := d.Decode(makecopy(data), makecopy(ecc)); err != nil || n != 2 {
+					b.Fail()
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkDecodeErasures128_16With16Erasures(b *testing.B) {
+	for _, alg := range algorithms {
+		b.Run(alg.String(), func(b *testing.B) {
+			b.StopTimer()
+			data := makecopy(Rand128)
+			ecc := make([]byte, 16)
+			e := NewEncoder(QRCodeField256, len(ecc))
+			e.Encode(data, ecc)
+			d := NewDecoder(QRCodeField256, UseAlgorithm(alg))
+			erasures := make([]int, len(ecc))
+			for i := range erasures {
+				erasures[i] = 8 * i
+				data[8*i] = data[8*i] + 1
+			}
+			b.SetBytes(int64(len(data) * b.N))
+			b.StartTimer()
+			for i := 0; i < b.N; i++ {
+				if n, m, err := d.DecodeErasures(makecopy(data), ecc, erasures); err != nil || n != 0 || m != len(ecc) {
+					b.Fail()
+				}
+			}
+		})
 	}
 }
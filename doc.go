@@ -20,4 +20,6 @@ limitations under the License. */
 package rs
 
 // BUG(maruel): It's far from complete. It can't accept total buffer size of
-// more than 255 bytes. So len(data)+len(ecc) must be under 256 bytes.
+// more than 255 bytes. So len(data)+len(ecc) must be under 256 bytes. Use
+// BlockCodec to protect larger buffers or WideField for codewords of up to
+// 65535 symbols.
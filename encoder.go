@@ -10,6 +10,8 @@ limitations under the License. */
 package rs
 
 import (
+	"fmt"
+
 	"rsc.io/qr/gf256"
 )
 
@@ -17,17 +19,92 @@ import (
 type Encoder interface {
 	// Encode calculates the ECC code for data and writes it into ecc.
 	Encode(data []byte, ecc []byte)
+	// EncodeChecked is the equivalent of Encode that returns an error
+	// wrapping ErrInvalidLength instead of misbehaving when ecc is not
+	// exactly as long as the number of ECC bytes the encoder was created with
+	// or when data and ecc are longer than 255 bytes.
+	EncodeChecked(data []byte, ecc []byte) error
 }
 
 type rSEncoder struct {
 	r *gf256.RSEncoder
+	c int
 }
 
 // NewEncoder generates a Reed-Solomon encoder that can generate ECC codes.
-func NewEncoder(f *Field, c int) Encoder {
-	return &rSEncoder{gf256.NewRSEncoder(f.f, c)}
+//
+// The generator polynomial roots can be changed with FirstRoot and
+// RootSpacing.
+func NewEncoder(f *Field, c int, opts ...Option) Encoder {
+	o := getOptions(255, opts)
+	if o.isDefault() {
+		return &rSEncoder{gf256.NewRSEncoder(f.f, c), c}
+	}
+	// gen = Π(x + α^(prim*(fcr+i))) for i in [0, c).
+	gen := getOne(f)
+	for i := 0; i < c; i++ {
+		gen = gen.Mul(&Poly{f, []byte{1, f.f.Exp(o.prim * (o.fcr + i) % 255)}})
+	}
+	return &rootsEncoder{f, gen.coefficients[1:]}
 }
 
 func (r *rSEncoder) Encode(data []byte, ecc []byte) {
 	r.r.ECC(data, ecc)
 }
+
+func (r *rSEncoder) EncodeChecked(data []byte, ecc []byte) error {
+	if err := checkEncode(data, ecc, r.c); err != nil {
+		return err
+	}
+	r.Encode(data, ecc)
+	return nil
+}
+
+// rootsEncoder supports any generator polynomial, contrary to
+// gf256.RSEncoder.
+type rootsEncoder struct {
+	f   *Field
+	gen []byte // Generator polynomial, without its leading 1 term.
+}
+
+func (r *rootsEncoder) EncodeChecked(data []byte, ecc []byte) error {
+	if err := checkEncode(data, ecc, len(r.gen)); err != nil {
+		return err
+	}
+	r.Encode(data, ecc)
+	return nil
+}
+
+func (r *rootsEncoder) Encode(data []byte, ecc []byte) {
+	if len(ecc) < len(r.gen) {
+		panic("rs: invalid check byte length")
+	}
+	ecc = ecc[:len(r.gen)]
+	if len(ecc) == 0 {
+		return
+	}
+	for i := range ecc {
+		ecc[i] = 0
+	}
+	// The ECC bytes are the remainder of data·x^c divided by the generator,
+	// calculated with a linear feedback shift register.
+	for _, d := range data {
+		feedback := d ^ ecc[0]
+		copy(ecc, ecc[1:])
+		ecc[len(ecc)-1] = 0
+		if feedback != 0 {
+			for j, g := range r.gen {
+				ecc[j] ^= r.f.f.Mul(feedback, g)
+			}
+		}
+	}
+}
+
+// checkEncode returns an error if ecc is not c bytes long or if data and ecc
+// don't fit in a codeword.
+func checkEncode(data, ecc []byte, c int) error {
+	if len(ecc) != c {
+		return fmt.Errorf("%w: expected %d ECC bytes, got %d", ErrInvalidLength, c, len(ecc))
+	}
+	return checkCodewordLen(data, ecc)
+}
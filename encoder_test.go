@@ -27,6 +27,7 @@ package rs
 
 import (
 	"bytes"
+	"errors"
 	"testing"
 )
 
@@ -37,6 +38,70 @@ func TestISO18004Example(t *testing.T) {
 	compare(t, QRCodeTestECC, actualEcc, "ECC differs")
 }
 
+// Data Matrix uses the roots α^1 to α^c. Tests the example "123456" given in
+// ISO/IEC 16022, Annex O.
+func TestFirstRoot(t *testing.T) {
+	ecc := make([]byte, 5)
+	NewEncoder(DataMatrixField256, len(ecc), FirstRoot(1)).Encode([]byte{142, 164, 186}, ecc)
+	compare(t, []byte{114, 25, 5, 88, 102}, ecc, "ECC differs")
+}
+
+func TestRootsDefault(t *testing.T) {
+	actualEcc := make([]byte, len(QRCodeTestECC))
+	NewEncoder(QRCodeField256, len(QRCodeTestECC), FirstRoot(255), RootSpacing(1)).Encode(QRCodeTestData, actualEcc)
+	compare(t, QRCodeTestECC, actualEcc, "ECC differs")
+	if _, ok := NewEncoder(QRCodeField256, 2, FirstRoot(-255)).(*rSEncoder); !ok {
+		t.Fatal("expected gf256 based encoder")
+	}
+}
+
+func TestEncodeRoots(t *testing.T) {
+	data := []struct{ fcr, prim int }{{1, 1}, {112, 11}, {-1, 2}, {0, 254}}
+	for _, line := range data {
+		ecc := make([]byte, 16)
+		NewEncoder(QRCodeField256, len(ecc), FirstRoot(line.fcr), RootSpacing(line.prim)).Encode(Rand128, ecc)
+		// A valid codeword evaluates to zero at every root of the generator.
+		codeword := &Poly{QRCodeField256, append(makecopy(Rand128), ecc...)}
+		for i := 0; i < len(ecc); i++ {
+			x := QRCodeField256.f.Exp(mod(line.prim*(line.fcr+i), 255))
+			if v := codeword.Evaluate(x); v != 0 {
+				t.Fatalf("fcr=%d, prim=%d: codeword(%d) = %d", line.fcr, line.prim, x, v)
+			}
+		}
+	}
+}
+
+func TestRootSpacingInvalid(t *testing.T) {
+	for _, prim := range []int{0, 3, 5, 17, 255} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("%d: expected panic", prim)
+				}
+			}()
+			NewEncoder(QRCodeField256, 2, RootSpacing(prim))
+		}()
+	}
+}
+
+func TestEncodeChecked(t *testing.T) {
+	for _, opts := range [][]Option{nil, {FirstRoot(1)}} {
+		e := NewEncoder(QRCodeField256, 10, opts...)
+		ecc := make([]byte, 10)
+		if err := e.EncodeChecked(QRCodeTestData, ecc); err != nil {
+			t.Fatal(err)
+		}
+		expected := make([]byte, 10)
+		e.Encode(QRCodeTestData, expected)
+		compare(t, expected, ecc, "ECC differs")
+		for _, line := range []struct{ data, ecc int }{{16, 9}, {16, 11}, {246, 10}} {
+			if err := e.EncodeChecked(make([]byte, line.data), make([]byte, line.ecc)); !errors.Is(err, ErrInvalidLength) {
+				t.Fatalf("%d, %d: %v", line.data, line.ecc, err)
+			}
+		}
+	}
+}
+
 func compare(t *testing.T, a []byte, b []byte, msg string) {
 	if !bytes.Equal(a, b) {
 		t.Fatalf("%s: %q != %q", msg, a, b)
@@ -0,0 +1,62 @@
+/* Copyright 2012 Marc-Antoine Ruel. Licensed under the Apache License, Version
+2.0 (the "License"); you may not use this file except in compliance with the
+License.  You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0. Unless required by applicable law or
+agreed to in writing, software distributed under the License is distributed on
+an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+or implied. See the License for the specific language governing permissions and
+limitations under the License. */
+
+package rs
+
+import (
+	"errors"
+	"strconv"
+)
+
+// The errors returned by the decoders and codecs wrap one of these errors, so
+// the cause of a failure can be checked with errors.Is.
+var (
+	// ErrTooManyErrors is returned when the errors and erasures exceed the
+	// correction capability of the ECC bytes, as detected from the degree of
+	// the error locator.
+	ErrTooManyErrors = errors.New("rs: too many errors")
+	// ErrKeyEquation is returned when the key equation has no valid solution,
+	// which also means there were too many errors.
+	ErrKeyEquation = errors.New("rs: no solution to the key equation")
+	// ErrBadLocation is returned when the roots of the error locator don't
+	// match its degree or are erasures, which also means there were too many
+	// errors.
+	ErrBadLocation = errors.New("rs: bad error location")
+	// ErrMiscorrection is returned when the decoder converged on a correction
+	// that is not a valid codeword, for example with an error located outside
+	// of a shortened codeword. It means there were more errors than the ECC
+	// bytes can correct. The input is not modified.
+	ErrMiscorrection = errors.New("rs: miscorrection detected")
+	// ErrInvalidErasure is returned when there are more erasures than ECC
+	// bytes, or an erasure position is out of range or duplicated.
+	ErrInvalidErasure = errors.New("rs: invalid erasure")
+	// ErrInvalidLength is returned when a buffer doesn't have the expected
+	// size.
+	ErrInvalidLength = errors.New("rs: invalid length")
+	// ErrInvalidSymbol is returned when a symbol is out of range for a
+	// WideField.
+	ErrInvalidSymbol = errors.New("rs: invalid symbol")
+)
+
+// BlockError is the error of a block that failed to decode. It is returned by
+// BlockCodec and CCSDS and can be checked with errors.As.
+type BlockError struct {
+	// Block is the index of the failed block.
+	Block int
+	// Err is the error returned by the decoder.
+	Err error
+}
+
+func (e *BlockError) Error() string {
+	return "rs: block " + strconv.Itoa(e.Block) + ": " + e.Err.Error()
+}
+
+func (e *BlockError) Unwrap() error {
+	return e.Err
+}
@@ -20,6 +20,9 @@ import (
 var QRCodeField256 = NewField(0x11D, 2)
 
 // Field is a wrapper to gf256.Field so the type doesn't leak in.
+//
+// Its methods expose the field arithmetic, so gf256 doesn't need to be
+// imported.
 type Field struct {
 	f *gf256.Field
 }
@@ -29,3 +32,89 @@ type Field struct {
 func NewField(poly int, α byte) *Field {
 	return &Field{gf256.NewField(poly, int(α))}
 }
+
+// Add returns x + y. In a field of characteristic 2, it is also the
+// subtraction.
+func (f *Field) Add(x, y byte) byte {
+	return x ^ y
+}
+
+// Mul returns x * y.
+func (f *Field) Mul(x, y byte) byte {
+	return f.f.Mul(x, y)
+}
+
+// Div returns x / y.
+//
+// It panics if y is zero.
+func (f *Field) Div(x, y byte) byte {
+	if y == 0 {
+		panic("rs: division by zero")
+	}
+	return f.f.Mul(x, f.f.Inv(y))
+}
+
+// Inv returns the multiplicative inverse of x. Inv(0) returns 0.
+func (f *Field) Inv(x byte) byte {
+	return f.f.Inv(x)
+}
+
+// Exp returns α^e. e can be negative.
+func (f *Field) Exp(e int) byte {
+	return f.f.Exp(mod(e, 255))
+}
+
+// Log returns the base-α logarithm of x, in [0, 254]. Log(0) returns -1.
+func (f *Field) Log(x byte) int {
+	return f.f.Log(x)
+}
+
+// Pow returns x^e. e can be negative if x is not zero. Pow(0, 0) returns 1
+// and Pow(0, e) returns 0 otherwise.
+func (f *Field) Pow(x byte, e int) byte {
+	if e == 0 {
+		return 1
+	}
+	if x == 0 {
+		return 0
+	}
+	return f.Exp(f.f.Log(x) * mod(e, 255))
+}
+
+// MulSlice sets out[i] = c * in[i] for every element of in.
+//
+// out must be at least as long as in.
+func (f *Field) MulSlice(c byte, in, out []byte) {
+	out = out[:len(in)]
+	switch c {
+	case 0:
+		for i := range out {
+			out[i] = 0
+		}
+	case 1:
+		copy(out, in)
+	default:
+		for i, v := range in {
+			out[i] = f.f.Mul(c, v)
+		}
+	}
+}
+
+// MulAddSlice sets out[i] += c * in[i] for every element of in. This is the
+// building block of matrix based erasure codes.
+//
+// out must be at least as long as in.
+func (f *Field) MulAddSlice(c byte, in, out []byte) {
+	out = out[:len(in)]
+	switch c {
+	case 0:
+	case 1:
+		for i, v := range in {
+			out[i] ^= v
+		}
+	default:
+		for i, v := range in {
+			out[i] ^= f.f.Mul(c, v)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+/* Copyright 2012 Marc-Antoine Ruel. Licensed under the Apache License, Version
+2.0 (the "License"); you may not use this file except in compliance with the
+License.  You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0. Unless required by applicable law or
+agreed to in writing, software distributed under the License is distributed on
+an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+or implied. See the License for the specific language governing permissions and
+limitations under the License. */
+
+package rs
+
+import (
+	"testing"
+)
+
+func TestFieldArithmetic(t *testing.T) {
+	f := QRCodeField256
+	if f.Add(0x53, 0xCA) != 0x99 {
+		t.Fatal("Add")
+	}
+	// x^7 * x = x^8 = x^4 + x^3 + x^2 + 1 modulo 0x11D.
+	if f.Mul(0x80, 2) != 0x1D {
+		t.Fatal("Mul")
+	}
+	if f.Exp(8) != 0x1D || f.Exp(255) != 1 || f.Exp(-1) != f.Exp(254) {
+		t.Fatal("Exp")
+	}
+	if f.Log(0x1D) != 8 || f.Log(1) != 0 || f.Log(0) != -1 {
+		t.Fatal("Log")
+	}
+	if f.Inv(0) != 0 {
+		t.Fatal("Inv(0)")
+	}
+	for x := 1; x < 256; x++ {
+		b := byte(x)
+		if f.Mul(b, f.Inv(b)) != 1 {
+			t.Fatalf("Inv(%d)", x)
+		}
+		if f.Div(f.Mul(b, 0x37), 0x37) != b || f.Div(b, b) != 1 {
+			t.Fatalf("Div(%d)", x)
+		}
+		if f.Pow(b, 3) != f.Mul(b, f.Mul(b, b)) || f.Pow(b, -1) != f.Inv(b) || f.Pow(b, 255) != 1 {
+			t.Fatalf("Pow(%d)", x)
+		}
+	}
+	if f.Pow(0, 0) != 1 || f.Pow(0, 3) != 0 {
+		t.Fatal("Pow(0)")
+	}
+}
+
+func TestFieldDivByZero(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic")
+		}
+	}()
+	QRCodeField256.Div(1, 0)
+}
+
+func TestFieldSlices(t *testing.T) {
+	f := QRCodeField256
+	in := makecopy(Rand128)
+	for _, c := range []byte{0, 1, 2, 0xA5} {
+		out := make([]byte, len(in)+1)
+		out[len(in)] = 42
+		f.MulSlice(c, in, out)
+		acc := makecopy(Rand128)
+		f.MulAddSlice(c, in, acc)
+		for i, v := range in {
+			if out[i] != f.Mul(c, v) {
+				t.Fatalf("MulSlice(%d)[%d]", c, i)
+			}
+			if acc[i] != v^f.Mul(c, v) {
+				t.Fatalf("MulAddSlice(%d)[%d]", c, i)
+			}
+		}
+		if out[len(in)] != 42 {
+			t.Fatal("MulSlice wrote past in")
+		}
+	}
+}
+
+func BenchmarkMulAddSlice(b *testing.B) {
+	b.StopTimer()
+	in := make([]byte, 4096)
+	for i := range in {
+		in[i] = Rand128[i%len(Rand128)]
+	}
+	out := make([]byte, len(in))
+	b.SetBytes(int64(len(in)))
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		QRCodeField256.MulAddSlice(0xA5, in, out)
+	}
+}
@@ -1,6 +1,6 @@
 module github.com/maruel/rs
 
-go 1.11
+go 1.13
 
 require (
 	github.com/bits-and-blooms/bitset v1.2.1
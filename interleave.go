@@ -0,0 +1,248 @@
+/* Copyright 2012 Marc-Antoine Ruel. Licensed under the Apache License, Version
+2.0 (the "License"); you may not use this file except in compliance with the
+License.  You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0. Unless required by applicable law or
+agreed to in writing, software distributed under the License is distributed on
+an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+or implied. See the License for the specific language governing permissions and
+limitations under the License. */
+
+package rs
+
+import (
+	"fmt"
+)
+
+// Interleave writes the depth codewords concatenated in src into dst so that
+// consecutive bytes of dst belong to different codewords: byte i of codeword j
+// is written at dst[i*depth+j].
+//
+// A burst of up to depth*c/2 bytes is then spread over the depth codewords,
+// each one getting at most c/2 errors. This is the layout used by QR codes,
+// CCSDS and CDs.
+//
+// src and dst must have the same length, a multiple of depth.
+func Interleave(dst, src []byte, depth int) {
+	k := checkInterleave(dst, src, depth)
+	for j := 0; j < depth; j++ {
+		for i, v := range src[j*k : (j+1)*k] {
+			dst[i*depth+j] = v
+		}
+	}
+}
+
+// Deinterleave is the reverse of Interleave. It writes the depth codewords of
+// src concatenated in dst.
+func Deinterleave(dst, src []byte, depth int) {
+	k := checkInterleave(dst, src, depth)
+	for j := 0; j < depth; j++ {
+		for i := range dst[j*k : (j+1)*k] {
+			dst[j*k+i] = src[i*depth+j]
+		}
+	}
+}
+
+// checkInterleave panics if the buffers can't be interleaved and returns the
+// length of each codeword.
+func checkInterleave(dst, src []byte, depth int) int {
+	if depth < 1 || len(dst) != len(src) || len(src)%depth != 0 {
+		panic(fmt.Sprintf("rs: can't interleave %d bytes into %d bytes with depth %d", len(src), len(dst), depth))
+	}
+	return len(src) / depth
+}
+
+// InterleavedCodec protects a block of depth RS(n,k) codewords whose data and
+// ECC bytes are interleaved, so that bursts of errors are spread over the
+// codewords.
+//
+// Data byte i belongs to codeword i%depth and so do the ECC bytes.
+type InterleavedCodec struct {
+	depth int
+	c     *Codec
+}
+
+// NewInterleavedCodec returns an InterleavedCodec of depth RS(n,k)
+// codewords.
+//
+// depth must be at least 1. See NewCodec for the other arguments.
+func NewInterleavedCodec(f *Field, n, k, depth int, opts ...Option) *InterleavedCodec {
+	if depth < 1 {
+		panic(fmt.Sprintf("rs: invalid interleaving depth: %d", depth))
+	}
+	return &InterleavedCodec{depth: depth, c: NewCodec(f, n, k, opts...)}
+}
+
+// Depth returns the number of interleaved codewords.
+func (c *InterleavedCodec) Depth() int {
+	return c.depth
+}
+
+// Codec returns the code of each codeword.
+func (c *InterleavedCodec) Codec() *Codec {
+	return c.c
+}
+
+// Encode calculates the interleaved ECC bytes of the interleaved data.
+//
+// data must be k*depth long and ecc (n-k)*depth long.
+func (c *InterleavedCodec) Encode(data, ecc []byte) error {
+	if err := c.check(data, ecc); err != nil {
+		return err
+	}
+	buf := make([]byte, c.c.K())
+	e := make([]byte, c.c.ECCLen())
+	for j := 0; j < c.depth; j++ {
+		c.gather(buf, data, j)
+		if err := c.c.Encode(buf, e); err != nil {
+			return err
+		}
+		c.scatter(ecc, e, j)
+	}
+	return nil
+}
+
+// Decode corrects the interleaved codewords in-place.
+//
+// Returns the number of errors corrected in each codeword. All the codewords
+// are processed even if some fail, in which case their count is -1 and a
+// *BlockError for the first failed codeword is returned.
+func (c *InterleavedCodec) Decode(data, ecc []byte) ([]int, error) {
+	if err := c.check(data, ecc); err != nil {
+		return nil, err
+	}
+	buf := make([]byte, c.c.K())
+	e := make([]byte, c.c.ECCLen())
+	counts := make([]int, c.depth)
+	var err error
+	for j := range counts {
+		c.gather(buf, data, j)
+		c.gather(e, ecc, j)
+		n, err2 := c.c.Decode(buf, e)
+		if err2 != nil {
+			counts[j] = -1
+			if err == nil {
+				err = &BlockError{j, err2}
+			}
+			continue
+		}
+		counts[j] = n
+		c.scatter(data, buf, j)
+		c.scatter(ecc, e, j)
+	}
+	return counts, err
+}
+
+// gather copies the bytes of codeword j in src to dst.
+func (c *InterleavedCodec) gather(dst, src []byte, j int) {
+	for i := range dst {
+		dst[i] = src[i*c.depth+j]
+	}
+}
+
+// scatter copies the bytes of codeword j in src to dst.
+func (c *InterleavedCodec) scatter(dst, src []byte, j int) {
+	for i, v := range src {
+		dst[i*c.depth+j] = v
+	}
+}
+
+// check verifies the buffer sizes.
+func (c *InterleavedCodec) check(data, ecc []byte) error {
+	if len(data) != c.c.K()*c.depth {
+		return fmt.Errorf("%w: expected %d interleaved data bytes, got %d", ErrInvalidLength, c.c.K()*c.depth, len(data))
+	}
+	if len(ecc) != c.c.ECCLen()*c.depth {
+		return fmt.Errorf("%w: expected %d interleaved ECC bytes, got %d", ErrInvalidLength, c.c.ECCLen()*c.depth, len(ecc))
+	}
+	return nil
+}
+
+// ConvolutionalInterleaver is a Forney convolutional interleaver or
+// deinterleaver, as used by DVB with 12 branches and a delay of 17 bytes
+// around RS(204,188) codewords.
+//
+// Consecutive bytes go through consecutive branches. Branch j of the
+// interleaver delays its bytes by j*delay bytes of the same branch and branch
+// j of the deinterleaver by (branches-1-j)*delay, so every byte is delayed by
+// the same Latency() bytes end to end. It has a lower latency and needs less
+// memory than a block interleaver spreading bursts as well.
+type ConvolutionalInterleaver struct {
+	fifos  [][]byte
+	pos    []int
+	branch int
+}
+
+// NewConvolutionalInterleaver returns an interleaver with the given number
+// of branches and delay unit, both at least 1. Its delay lines start filled
+// with zeros.
+func NewConvolutionalInterleaver(branches, delay int) *ConvolutionalInterleaver {
+	return newConvolutional(branches, delay, false)
+}
+
+// NewConvolutionalDeinterleaver returns the deinterleaver matching
+// NewConvolutionalInterleaver(branches, delay).
+func NewConvolutionalDeinterleaver(branches, delay int) *ConvolutionalInterleaver {
+	return newConvolutional(branches, delay, true)
+}
+
+func newConvolutional(branches, delay int, reverse bool) *ConvolutionalInterleaver {
+	if branches < 1 || delay < 1 {
+		panic(fmt.Sprintf("rs: invalid convolutional interleaver with %d branches and delay %d", branches, delay))
+	}
+	c := &ConvolutionalInterleaver{fifos: make([][]byte, branches), pos: make([]int, branches)}
+	for j := range c.fifos {
+		l := j
+		if reverse {
+			l = branches - 1 - j
+		}
+		c.fifos[j] = make([]byte, l*delay)
+	}
+	return c
+}
+
+// Latency returns the end to end delay in bytes of an interleaver and its
+// deinterleaver, branches*(branches-1)*delay. This is the number of leading
+// bytes from the initial state of the delay lines in the deinterleaved
+// stream.
+func (c *ConvolutionalInterleaver) Latency() int {
+	l := 0
+	for _, f := range c.fifos {
+		l += len(f)
+	}
+	return l * 2
+}
+
+// Transform passes src through the delay lines into dst, which must be at
+// least as long as src. dst and src can be the same slice.
+//
+// The state is kept between calls, so a stream can be processed in chunks of
+// any size.
+func (c *ConvolutionalInterleaver) Transform(dst, src []byte) {
+	dst = dst[:len(src)]
+	for i, v := range src {
+		f := c.fifos[c.branch]
+		if len(f) != 0 {
+			p := c.pos[c.branch]
+			v, f[p] = f[p], v
+			if p++; p == len(f) {
+				p = 0
+			}
+			c.pos[c.branch] = p
+		}
+		dst[i] = v
+		if c.branch++; c.branch == len(c.fifos) {
+			c.branch = 0
+		}
+	}
+}
+
+// Reset clears the delay lines and restarts at the first branch.
+func (c *ConvolutionalInterleaver) Reset() {
+	for j, f := range c.fifos {
+		for i := range f {
+			f[i] = 0
+		}
+		c.pos[j] = 0
+	}
+	c.branch = 0
+}
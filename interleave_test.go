@@ -0,0 +1,170 @@
+/* Copyright 2012 Marc-Antoine Ruel. Licensed under the Apache License, Version
+2.0 (the "License"); you may not use this file except in compliance with the
+License.  You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0. Unless required by applicable law or
+agreed to in writing, software distributed under the License is distributed on
+an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+or implied. See the License for the specific language governing permissions and
+limitations under the License. */
+
+package rs
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestInterleave(t *testing.T) {
+	src := []byte{0, 1, 2, 10, 11, 12}
+	dst := make([]byte, len(src))
+	Interleave(dst, src, 2)
+	compare(t, dst, []byte{0, 10, 1, 11, 2, 12}, "Interleave differs")
+	back := make([]byte, len(src))
+	Deinterleave(back, dst, 2)
+	compare(t, back, src, "Deinterleave differs")
+	Interleave(dst, src, 1)
+	compare(t, dst, src, "Depth 1 differs")
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic")
+		}
+	}()
+	Interleave(make([]byte, 5), make([]byte, 5), 2)
+}
+
+func TestInterleavedCodec(t *testing.T) {
+	c := NewInterleavedCodec(QRCodeField256, 26, 16, 4)
+	if c.Depth() != 4 || c.Codec().K() != 16 {
+		t.Fatal(c.Depth(), c.Codec().K())
+	}
+	golden := makecopy(Rand128[:64])
+	goldenECC := make([]byte, 40)
+	if err := c.Encode(golden, goldenECC); err != nil {
+		t.Fatal(err)
+	}
+	// Each codeword is valid once deinterleaved.
+	data := make([]byte, len(golden))
+	ecc := make([]byte, len(goldenECC))
+	Deinterleave(data, golden, 4)
+	Deinterleave(ecc, goldenECC, 4)
+	for j := 0; j < 4; j++ {
+		if ok, err := c.Codec().Verify(data[j*16:(j+1)*16], ecc[j*10:(j+1)*10]); !ok || err != nil {
+			t.Fatal(j, ok, err)
+		}
+	}
+	// A burst of 20 bytes is 5 errors per codeword.
+	data = makecopy(golden)
+	ecc = makecopy(goldenECC)
+	for i := 50; i < 70; i++ {
+		if i < len(data) {
+			data[i] ^= 0x5A
+		} else {
+			ecc[i-len(data)] ^= 0x5A
+		}
+	}
+	counts, err := c.Decode(data, ecc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(counts) != 4 || counts[0] != 5 || counts[3] != 5 {
+		t.Fatal(counts)
+	}
+	compare(t, data, golden, "Data differs")
+	compare(t, ecc, goldenECC, "ECC differs")
+	// 6 errors in codeword 2 only.
+	for _, i := range []int{50, 54, 58, 62} {
+		data[i] ^= 0x5A
+	}
+	ecc[2] ^= 0x5A
+	ecc[6] ^= 0x5A
+	counts, err = c.Decode(data, ecc)
+	var blockErr *BlockError
+	if !errors.As(err, &blockErr) || blockErr.Block != 2 || counts[2] != -1 || counts[0] != 0 {
+		t.Fatal(counts, err)
+	}
+}
+
+func TestInterleavedCodecInvalid(t *testing.T) {
+	c := NewInterleavedCodec(QRCodeField256, 26, 16, 4)
+	if err := c.Encode(make([]byte, 63), make([]byte, 40)); !errors.Is(err, ErrInvalidLength) {
+		t.Fatal(err)
+	}
+	if _, err := c.Decode(make([]byte, 64), make([]byte, 10)); !errors.Is(err, ErrInvalidLength) {
+		t.Fatal(err)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic")
+		}
+	}()
+	NewInterleavedCodec(QRCodeField256, 26, 16, 0)
+}
+
+func TestConvolutionalInterleaver(t *testing.T) {
+	i := NewConvolutionalInterleaver(3, 2)
+	d := NewConvolutionalDeinterleaver(3, 2)
+	if i.Latency() != 12 || d.Latency() != 12 {
+		t.Fatal(i.Latency(), d.Latency())
+	}
+	src := makecopy(Rand128)
+	buf := make([]byte, len(src))
+	// Process in irregular chunks, in-place for the deinterleaver.
+	for s := 0; s < len(src); s += 7 {
+		e := s + 7
+		if e > len(src) {
+			e = len(src)
+		}
+		i.Transform(buf[s:e], src[s:e])
+		d.Transform(buf[s:e], buf[s:e])
+	}
+	compare(t, buf[:12], make([]byte, 12), "Initial state differs")
+	compare(t, buf[12:], src[:len(src)-12], "Data differs")
+	d.Reset()
+	i.Reset()
+	i.Transform(buf, src)
+	compare(t, buf[:3], []byte{src[0], 0, 0}, "Interleaved differs")
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic")
+		}
+	}()
+	NewConvolutionalInterleaver(0, 1)
+}
+
+// Tests the DVB setup: RS(204,188) shortened codewords through a 12 branches
+// interleaver with a delay of 17 bytes, which corrects bursts of up to 12*8
+// bytes.
+func TestConvolutionalInterleaverDVB(t *testing.T) {
+	const packets = 20
+	c := NewCodec(QRCodeField256, 204, 188)
+	i := NewConvolutionalInterleaver(12, 17)
+	d := NewConvolutionalDeinterleaver(12, 17)
+	// The latency is exactly 11 packets.
+	if i.Latency() != 11*204 {
+		t.Fatal(i.Latency())
+	}
+	golden := make([]byte, (packets+11)*204)
+	for p := 0; p < packets; p++ {
+		packet := golden[p*204 : (p+1)*204]
+		for j := range packet[:188] {
+			packet[j] = Rand128[(p+j)%len(Rand128)]
+		}
+		if err := c.Encode(packet[:188], packet[188:]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	stream := make([]byte, len(golden))
+	i.Transform(stream, golden)
+	for j := 1000; j < 1000+12*8; j++ {
+		stream[j] ^= 0xFF
+	}
+	d.Transform(stream, stream)
+	stream = stream[11*204:]
+	for p := 0; p < packets; p++ {
+		packet := stream[p*204 : (p+1)*204]
+		if _, err := c.Decode(packet[:188], packet[188:]); err != nil {
+			t.Fatal(p, err)
+		}
+	}
+	compare(t, stream, golden[:packets*204], "Data differs")
+}
@@ -0,0 +1,157 @@
+/* Copyright 2012 Marc-Antoine Ruel. Licensed under the Apache License, Version
+2.0 (the "License"); you may not use this file except in compliance with the
+License.  You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0. Unless required by applicable law or
+agreed to in writing, software distributed under the License is distributed on
+an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+or implied. See the License for the specific language governing permissions and
+limitations under the License. */
+
+package rs
+
+import (
+	"strconv"
+)
+
+// Option configures an Encoder or a Decoder.
+//
+// The encoder and the decoder of a code must be created with the same options.
+type Option func(*options)
+
+// FirstRoot sets the first consecutive root of the generator polynomial, as a
+// power of α. The roots are α^(prim*(fcr+i)) for i in [0, len(ecc)).
+//
+// The default is 0, as used by QR codes. Many libfec based systems use 1 and
+// CCSDS uses 112.
+func FirstRoot(fcr int) Option {
+	return func(o *options) {
+		o.fcr = fcr
+	}
+}
+
+// RootSpacing sets the spacing prim between the consecutive roots of the
+// generator polynomial, as a power of α.
+//
+// The default is 1. It must be coprime with the number of non-zero elements
+// in the field, e.g. 255 for a Field. CCSDS uses 11.
+func RootSpacing(prim int) Option {
+	return func(o *options) {
+		o.prim = prim
+	}
+}
+
+// UseAlgorithm selects the algorithm used by a Decoder to solve the key
+// equation. The default is Euclidean. It is ignored by encoders, by the
+// decoders created with NewWideDecoder, which always use Euclidean, and by the
+// ones created with NewPrimeDecoder, which always use BerlekampMassey.
+func UseAlgorithm(a Algorithm) Option {
+	return func(o *options) {
+		o.alg = a
+	}
+}
+
+// CheckMiscorrection makes decoders recalculate the syndromes after
+// correction and return ErrMiscorrection if they are not all zero, instead of
+// modifying the input.
+//
+// This costs a second syndrome calculation. It catches a locator inconsistent
+// with the syndromes; it can't catch a received word that is within the
+// correction capability of another codeword.
+func CheckMiscorrection(check bool) Option {
+	return func(o *options) {
+		o.recheck = check
+	}
+}
+
+// Algorithm is an algorithm to solve the key equation, which finds the error
+// locator and evaluator polynomials from the syndromes.
+//
+// Both algorithms share the same Chien search and Forney's formula.
+type Algorithm int
+
+const (
+	// Euclidean is Sugiyama's algorithm based on the extended Euclidean
+	// algorithm.
+	Euclidean Algorithm = iota
+	// BerlekampMassey is the Berlekamp-Massey algorithm. It allocates less
+	// memory.
+	BerlekampMassey
+)
+
+func (a Algorithm) String() string {
+	switch a {
+	case Euclidean:
+		return "Euclidean"
+	case BerlekampMassey:
+		return "BerlekampMassey"
+	default:
+		return "Algorithm(" + strconv.Itoa(int(a)) + ")"
+	}
+}
+
+type options struct {
+	alg     Algorithm
+	fcr     int
+	prim    int
+	recheck bool
+	// iprim is the inverse of prim modulo the field order; it converts the
+	// log of an error location back to a position.
+	iprim int
+}
+
+// getOptions processes opts for a field with order non-zero elements.
+//
+// It panics if the options are invalid.
+func getOptions(order int, opts []Option) options {
+	o := options{prim: 1}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	o.fcr = mod(o.fcr, order)
+	o.prim = mod(o.prim, order)
+	o.iprim = invmod(o.prim, order)
+	if o.alg != Euclidean && o.alg != BerlekampMassey {
+		panic("rs: invalid " + o.alg.String())
+	}
+	if o.iprim == 0 {
+		panic("rs: root spacing " + strconv.Itoa(o.prim) + " is not coprime with " + strconv.Itoa(order))
+	}
+	return o
+}
+
+// isDefault returns true if the generator polynomial is Π(x + α^i), as
+// implemented by rsc.io/qr/gf256.
+func (o *options) isDefault() bool {
+	return o.fcr == 0 && o.prim == 1
+}
+
+// mod returns a modulo n, always positive.
+func mod(a, n int) int {
+	a %= n
+	if a < 0 {
+		a += n
+	}
+	return a
+}
+
+// mulmod returns a*b modulo n, always positive. The product is calculated on
+// 64 bits since it overflows an int on 32 bits platforms in GF(2^16).
+func mulmod(a, b, n int) int {
+	return int(int64(mod(a, n)) * int64(mod(b, n)) % int64(n))
+}
+
+// invmod returns the inverse of a modulo n with the extended Euclidean
+// algorithm, or 0 if a and n are not coprime.
+func invmod(a, n int) int {
+	t, newT := 0, 1
+	r, newR := n, mod(a, n)
+	for newR != 0 {
+		q := r / newR
+		t, newT = newT, t-q*newT
+		r, newR = newR, r-q*newR
+	}
+	if r != 1 {
+		return 0
+	}
+	return mod(t, n)
+}
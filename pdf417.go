@@ -0,0 +1,42 @@
+/* Copyright 2012 Marc-Antoine Ruel. Licensed under the Apache License, Version
+2.0 (the "License"); you may not use this file except in compliance with the
+License.  You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0. Unless required by applicable law or
+agreed to in writing, software distributed under the License is distributed on
+an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+or implied. See the License for the specific language governing permissions and
+limitations under the License. */
+
+package rs
+
+import (
+	"fmt"
+)
+
+// PDF417Field is GF(929), the prime field used by PDF417 with the generator
+// 3. See ISO/IEC 15438, section 5.10.
+var PDF417Field = NewPrimeField(929, 3)
+
+// PDF417ECCLen returns the number of error correction codewords of the
+// PDF417 error correction level, between 0 and 8 inclusively: 2^(level+1).
+func PDF417ECCLen(level int) int {
+	if level < 0 || level > 8 {
+		panic(fmt.Sprintf("rs: invalid PDF417 error correction level: %d", level))
+	}
+	return 2 << uint(level)
+}
+
+// NewPDF417Encoder returns an encoder of the error correction codewords of
+// the PDF417 error correction level.
+//
+// The generator polynomial roots are 3^1 to 3^k, with k = PDF417ECCLen(level).
+// The data includes the symbol length descriptor and the pad codewords.
+func NewPDF417Encoder(level int) WideEncoder {
+	return NewPrimeEncoder(PDF417Field, PDF417ECCLen(level), FirstRoot(1))
+}
+
+// NewPDF417Decoder returns a decoder of PDF417 codewords of any error
+// correction level.
+func NewPDF417Decoder() WideDecoder {
+	return NewPrimeDecoder(PDF417Field, FirstRoot(1))
+}
@@ -0,0 +1,143 @@
+/* Copyright 2012 Marc-Antoine Ruel. Licensed under the Apache License, Version
+2.0 (the "License"); you may not use this file except in compliance with the
+License.  You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0. Unless required by applicable law or
+agreed to in writing, software distributed under the License is distributed on
+an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+or implied. See the License for the specific language governing permissions and
+limitations under the License. */
+
+package rs
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// Test vector from ZXing's PDF417 ErrorCorrectionTestCase, error correction
+// level 5.
+var (
+	pdf417TestData = []uint16{
+		48, 901, 56, 141, 627, 856, 330, 69, 244, 900, 852, 169, 843, 895, 852, 895,
+		913, 154, 845, 778, 387, 89, 869, 901, 219, 474, 543, 650, 169, 201, 9, 160,
+		35, 70, 900, 900, 900, 900, 900, 900, 900, 900, 900, 900, 900, 900, 900, 900,
+	}
+	pdf417TestECC = []uint16{
+		769, 843, 591, 910, 605, 206, 706, 917, 371, 469, 79, 718, 47, 777, 249, 262,
+		193, 620, 597, 477, 450, 806, 908, 309, 153, 871, 686, 838, 185, 674, 68, 679,
+		691, 794, 497, 479, 234, 250, 496, 43, 347, 582, 882, 536, 322, 317, 273, 194,
+		917, 237, 420, 859, 340, 115, 222, 808, 866, 836, 417, 121, 833, 459, 64, 159,
+	}
+)
+
+func TestPDF417Encode(t *testing.T) {
+	ecc := make([]uint16, PDF417ECCLen(5))
+	NewPDF417Encoder(5).Encode(pdf417TestData, ecc)
+	if !reflect.DeepEqual(ecc, pdf417TestECC) {
+		t.Fatalf("%v != %v", ecc, pdf417TestECC)
+	}
+}
+
+// The generator polynomial coefficients of ISO/IEC 15438, Annex F, from the
+// constant term up without the leading 1.
+func TestPDF417Generator(t *testing.T) {
+	data := [][]uint16{
+		{27, 917},
+		{522, 568, 723, 809},
+		{237, 308, 436, 284, 646, 653, 428, 379},
+	}
+	for level, expected := range data {
+		gen := NewPDF417Encoder(level).(*primeEncoder).gen
+		for i, v := range expected {
+			if gen[len(gen)-1-i] != v {
+				t.Fatalf("level %d: %v", level, gen)
+			}
+		}
+	}
+}
+
+func TestPDF417Decode(t *testing.T) {
+	d := NewPDF417Decoder()
+	for nbErasures := 0; nbErasures <= len(pdf417TestECC); nbErasures += 4 {
+		nbErrors := (len(pdf417TestECC) - nbErasures) / 2
+		data := append([]uint16{}, pdf417TestData...)
+		ecc := append([]uint16{}, pdf417TestECC...)
+		perm := rand.Perm(len(data) + len(ecc))
+		for _, p := range perm[:nbErasures+nbErrors] {
+			v := uint16(1 + rand.Intn(928))
+			if p < len(data) {
+				data[p] = PDF417Field.add(data[p], v)
+			} else {
+				ecc[p-len(data)] = PDF417Field.add(ecc[p-len(data)], v)
+			}
+		}
+		n, m, err := d.DecodeErasures(data, ecc, perm[:nbErasures])
+		if err != nil || n != nbErrors || m != nbErasures {
+			t.Fatalf("expected %d errors, %d erasures; got %d, %d: %v", nbErrors, nbErasures, n, m, err)
+		}
+		if !reflect.DeepEqual(data, pdf417TestData) || !reflect.DeepEqual(ecc, pdf417TestECC) {
+			t.Fatal("not corrected")
+		}
+	}
+}
+
+func TestPDF417Levels(t *testing.T) {
+	d := NewPrimeDecoder(PDF417Field, FirstRoot(1), CheckMiscorrection(true))
+	for level := 0; level <= 8; level++ {
+		ecc := make([]uint16, PDF417ECCLen(level))
+		// The largest symbol has 928 codewords.
+		golden := make([]uint16, 928-len(ecc))
+		for i := range golden {
+			golden[i] = uint16(rand.Intn(929))
+		}
+		NewPDF417Encoder(level).Encode(golden, ecc)
+		goldenECC := append([]uint16{}, ecc...)
+		data := append([]uint16{}, golden...)
+		for _, p := range rand.Perm(len(data))[:len(ecc)/2] {
+			data[p] = PDF417Field.sub(data[p], uint16(1+rand.Intn(928)))
+		}
+		if n, err := d.Decode(data, ecc); err != nil || n != len(ecc)/2 {
+			t.Fatalf("level %d: %d, %v", level, n, err)
+		}
+		if !reflect.DeepEqual(data, golden) || !reflect.DeepEqual(ecc, goldenECC) {
+			t.Fatalf("level %d: not corrected", level)
+		}
+	}
+}
+
+func TestPDF417Invalid(t *testing.T) {
+	d := NewPDF417Decoder()
+	if _, err := d.Decode([]uint16{1, 929}, make([]uint16, 2)); !errors.Is(err, ErrInvalidSymbol) {
+		t.Fatal(err)
+	}
+	if _, err := d.Decode(make([]uint16, 927), make([]uint16, 2)); !errors.Is(err, ErrInvalidLength) {
+		t.Fatal(err)
+	}
+	if _, _, err := d.DecodeErasures(make([]uint16, 10), make([]uint16, 2), []int{1, 2, 3}); !errors.Is(err, ErrInvalidErasure) {
+		t.Fatal(err)
+	}
+	// More errors than the ECC can correct are either detected or miscorrected.
+	for i := 0; i < 100; i++ {
+		data := append([]uint16{}, pdf417TestData...)
+		ecc := make([]uint16, 8)
+		NewPDF417Encoder(2).Encode(data, ecc)
+		for _, p := range rand.Perm(len(data))[:5+rand.Intn(10)] {
+			data[p] = PDF417Field.add(data[p], uint16(1+rand.Intn(928)))
+		}
+		if _, err := d.Decode(data, ecc); err == nil && reflect.DeepEqual(data, pdf417TestData) {
+			t.Fatal("corrected more errors than possible")
+		}
+	}
+	for _, level := range []int{-1, 9} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("%d: expected panic", level)
+				}
+			}()
+			PDF417ECCLen(level)
+		}()
+	}
+}
@@ -35,7 +35,12 @@ import (
 	"fmt"
 )
 
-type poly struct {
+// Poly is a polynomial whose coefficients are elements of a Field.
+//
+// Instances are immutable; every operation returns a new polynomial. They must
+// be created with NewPoly or NewMonomial: the zero value Poly{} has no field
+// nor coefficients, it is not the zero polynomial and its methods panic.
+type Poly struct {
 	field        *Field
 	coefficients []byte // In reverse order.
 }
@@ -43,17 +48,44 @@ type poly struct {
 var zero = []byte{0}
 var one = []byte{1}
 
+// NewPoly returns the polynomial with coefficients arranged from most
+// significant (highest-power term) coefficient to least significant. For
+// example {1, 0, 3} is x^2 + 3.
+//
+// coefficients is copied. An empty slice returns the zero polynomial.
+func NewPoly(field *Field, coefficients []byte) *Poly {
+	if len(coefficients) == 0 {
+		return getZero(field)
+	}
+	c := make([]byte, len(coefficients))
+	copy(c, coefficients)
+	return makePoly(field, c)
+}
+
+// NewMonomial returns the monomial representing coefficient * x^degree.
+//
+// It panics if degree is negative.
+func NewMonomial(field *Field, degree int, coefficient byte) *Poly {
+	checkDegree(degree)
+	if coefficient == 0 {
+		return getZero(field)
+	}
+	coefficients := make([]byte, degree+1)
+	coefficients[0] = coefficient
+	return &Poly{field, coefficients}
+}
+
 // |coefficients| representing elements of GF(size), arranged from most
 // significant (highest-power term) coefficient to least significant.
-func makePoly(field *Field, coefficients []byte) *poly {
+func makePoly(field *Field, coefficients []byte) *Poly {
 	if len(coefficients) == 0 {
 		return nil
 	}
-	obj := &poly{field: field}
+	obj := &Poly{field: field}
 	if len(coefficients) > 1 && coefficients[0] == 0 {
 		// Leading term must be non-zero for anything except the constant polynomial "0".
 		firstNonZero := 1
-		for coefficients[firstNonZero] == 0 && firstNonZero < len(coefficients) {
+		for firstNonZero < len(coefficients) && coefficients[firstNonZero] == 0 {
 			firstNonZero++
 		}
 		if firstNonZero == len(coefficients) {
@@ -68,46 +100,58 @@ func makePoly(field *Field, coefficients []byte) *poly {
 	return obj
 }
 
-func getZero(field *Field) *poly {
-	return &poly{field, zero}
+// checkDegree panics if degree is not a valid monomial degree.
+func checkDegree(degree int) {
+	if degree < 0 {
+		panic(fmt.Sprintf("rs: negative monomial degree: %d", degree))
+	}
+}
+
+func getZero(field *Field) *Poly {
+	return &Poly{field, zero}
 }
 
-func getOne(field *Field) *poly {
-	return &poly{field, one}
+func getOne(field *Field) *Poly {
+	return &Poly{field, one}
 }
 
-func (p *poly) degree() int {
-	return len(p.coefficients) - 1
+// Field returns the field of the coefficients.
+func (p *Poly) Field() *Field {
+	return p.field
 }
 
-// Returns the monomial representing coefficient * x^degree.
-func buildMonomial(field *Field, degree int, coefficient byte) *poly {
-	if degree < 0 {
-		return nil
-	}
-	if coefficient == 0 {
-		return getZero(field)
-	}
-	coefficients := make([]byte, degree+1)
-	coefficients[0] = coefficient
-	return &poly{field, coefficients}
+// Coefficients returns a copy of the coefficients, from the highest-power
+// term to the constant term.
+func (p *Poly) Coefficients() []byte {
+	c := make([]byte, len(p.coefficients))
+	copy(c, p.coefficients)
+	return c
 }
 
-// Returns true iff this polynomial is the monomial "0".
-func (p *poly) isZero() bool {
+// Degree returns the degree of the polynomial. The zero polynomial has a
+// degree of 0.
+func (p *Poly) Degree() int {
+	return len(p.coefficients) - 1
+}
+
+// IsZero returns true iff this polynomial is the monomial "0".
+func (p *Poly) IsZero() bool {
 	return p.coefficients[0] == 0
 }
 
-// Returns coefficient of x^degree term in this polynomial.
-func (p *poly) getCoefficient(degree int) byte {
+// Coefficient returns the coefficient of x^degree term in this polynomial.
+func (p *Poly) Coefficient(degree int) byte {
+	if degree < 0 || degree >= len(p.coefficients) {
+		return 0
+	}
 	return p.coefficients[len(p.coefficients)-1-degree]
 }
 
-// Returns evaluation of this polynomial at a given point.
-func (p *poly) evaluateAt(a byte) byte {
+// Evaluate returns evaluation of this polynomial at a given point.
+func (p *Poly) Evaluate(a byte) byte {
 	if a == 0 {
 		// Just return the x^0 coefficient
-		return p.getCoefficient(0)
+		return p.Coefficient(0)
 	}
 	if a == 1 {
 		// Just the sum of the coefficients.
@@ -124,11 +168,13 @@ func (p *poly) evaluateAt(a byte) byte {
 	return result
 }
 
-func (p *poly) add(other *poly) *poly {
-	if p.isZero() {
+// Add returns p + other. In a field of characteristic 2, it is also the
+// subtraction.
+func (p *Poly) Add(other *Poly) *Poly {
+	if p.IsZero() {
 		return other
 	}
-	if other.isZero() {
+	if other.IsZero() {
 		return p
 	}
 	smaller := p.coefficients
@@ -147,8 +193,9 @@ func (p *poly) add(other *poly) *poly {
 	return makePoly(p.field, sumDiff)
 }
 
-func (p *poly) mulPoly(other *poly) *poly {
-	if p.isZero() || other.isZero() {
+// Mul returns p * other.
+func (p *Poly) Mul(other *Poly) *Poly {
+	if p.IsZero() || other.IsZero() {
 		return getZero(p.field)
 	}
 	aCoefficients := p.coefficients
@@ -163,7 +210,8 @@ func (p *poly) mulPoly(other *poly) *poly {
 	return makePoly(p.field, product)
 }
 
-func (p *poly) mulScalar(scalar byte) *poly {
+// MulScalar returns p * scalar.
+func (p *Poly) MulScalar(scalar byte) *Poly {
 	if scalar == 0 {
 		return getZero(p.field)
 	}
@@ -177,10 +225,11 @@ func (p *poly) mulScalar(scalar byte) *poly {
 	return makePoly(p.field, product)
 }
 
-func (p *poly) mulByMonomial(degree int, coefficient byte) *poly {
-	if degree < 0 {
-		return nil
-	}
+// MulMonomial returns p * coefficient * x^degree.
+//
+// It panics if degree is negative.
+func (p *Poly) MulMonomial(degree int, coefficient byte) *Poly {
+	checkDegree(degree)
 	if coefficient == 0 {
 		return getZero(p.field)
 	}
@@ -192,27 +241,99 @@ func (p *poly) mulByMonomial(degree int, coefficient byte) *poly {
 	return makePoly(p.field, product)
 }
 
-func (p *poly) divide(divisor *poly) (q *poly, r *poly) {
-	if divisor.isZero() {
-		// "Divide by 0".
-		return nil, nil
+// Returns the remainder of the division by x^degree, e.g. only the terms of
+// degree lower than degree.
+func (p *Poly) modMonomial(degree int) *Poly {
+	if len(p.coefficients) <= degree {
+		return p
+	}
+	return makePoly(p.field, p.coefficients[len(p.coefficients)-degree:])
+}
+
+// Divide returns the quotient q and the remainder r of p / divisor, such that
+// p = q * divisor + r.
+//
+// It panics if divisor is the zero polynomial.
+func (p *Poly) Divide(divisor *Poly) (q *Poly, r *Poly) {
+	if divisor.IsZero() {
+		panic("rs: division by the zero polynomial")
 	}
 	quotient := getZero(p.field)
 	remainder := p
 
-	denominatorLeadingTerm := divisor.getCoefficient(divisor.degree())
+	denominatorLeadingTerm := divisor.Coefficient(divisor.Degree())
 	inverseDenominatorLeadingTerm := p.field.f.Inv(denominatorLeadingTerm)
-	for remainder.degree() >= divisor.degree() && !remainder.isZero() {
-		degreeDifference := remainder.degree() - divisor.degree()
-		scale := p.field.f.Mul(remainder.getCoefficient(remainder.degree()), inverseDenominatorLeadingTerm)
-		term := divisor.mulByMonomial(degreeDifference, scale)
-		iterationQuotient := buildMonomial(p.field, degreeDifference, scale)
-		quotient = quotient.add(iterationQuotient)
-		remainder = remainder.add(term)
+	for remainder.Degree() >= divisor.Degree() && !remainder.IsZero() {
+		degreeDifference := remainder.Degree() - divisor.Degree()
+		scale := p.field.f.Mul(remainder.Coefficient(remainder.Degree()), inverseDenominatorLeadingTerm)
+		term := divisor.MulMonomial(degreeDifference, scale)
+		iterationQuotient := NewMonomial(p.field, degreeDifference, scale)
+		quotient = quotient.Add(iterationQuotient)
+		remainder = remainder.Add(term)
 	}
 	return quotient, remainder
 }
 
-func (p *poly) String() string {
-	return fmt.Sprintf("poly{%v}", p.coefficients)
+// GCD returns the monic greatest common divisor of p and other.
+//
+// The GCD of two zero polynomials is the zero polynomial.
+func (p *Poly) GCD(other *Poly) *Poly {
+	a, b := p, other
+	for !b.IsZero() {
+		_, r := a.Divide(b)
+		a, b = b, r
+	}
+	if a.IsZero() {
+		return a
+	}
+	return a.MulScalar(p.field.f.Inv(a.coefficients[0]))
+}
+
+// Derivative returns the formal derivative of p.
+//
+// In a field of characteristic 2, the terms of even power vanish.
+func (p *Poly) Derivative() *Poly {
+	if len(p.coefficients) == 1 {
+		return getZero(p.field)
+	}
+	d := make([]byte, len(p.coefficients)-1)
+	for i := range d {
+		// d[i] is the coefficient of x^(degree-1-i), coming from the term
+		// x^(degree-i) multiplied by (degree-i), e.g. 0 or 1 modulo 2.
+		if (len(d)-i)&1 == 1 {
+			d[i] = p.coefficients[i]
+		}
+	}
+	return makePoly(p.field, d)
+}
+
+// Compose returns p(other(x)).
+func (p *Poly) Compose(other *Poly) *Poly {
+	// Horner's method.
+	result := getZero(p.field)
+	for _, c := range p.coefficients {
+		result = result.Mul(other).Add(&Poly{p.field, []byte{c}})
+	}
+	return result
+}
+
+// Roots returns the distinct roots of p in increasing order, found by
+// exhaustive search over the field.
+//
+// The zero polynomial returns nil since every element is a root.
+func (p *Poly) Roots() []byte {
+	if p.IsZero() {
+		return nil
+	}
+	var roots []byte
+	for i := 0; i < 256 && len(roots) < p.Degree(); i++ {
+		if p.Evaluate(byte(i)) == 0 {
+			roots = append(roots, byte(i))
+		}
+	}
+	return roots
+}
+
+func (p *Poly) String() string {
+	return fmt.Sprintf("Poly{%v}", p.coefficients)
 }
@@ -0,0 +1,137 @@
+/* Copyright 2012 Marc-Antoine Ruel. Licensed under the Apache License, Version
+2.0 (the "License"); you may not use this file except in compliance with the
+License.  You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0. Unless required by applicable law or
+agreed to in writing, software distributed under the License is distributed on
+an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+or implied. See the License for the specific language governing permissions and
+limitations under the License. */
+
+package rs
+
+import (
+	"testing"
+)
+
+func TestNewPoly(t *testing.T) {
+	c := []byte{0, 0, 1, 0, 3}
+	p := NewPoly(QRCodeField256, c)
+	c[2] = 2
+	if p.Degree() != 2 || p.Coefficient(2) != 1 || p.Coefficient(1) != 0 || p.Coefficient(0) != 3 || p.Coefficient(3) != 0 {
+		t.Fatal(p)
+	}
+	compare(t, []byte{1, 0, 3}, p.Coefficients(), "Coefficients differ")
+	if p.Field() != QRCodeField256 {
+		t.Fatal("wrong field")
+	}
+	if z := NewPoly(QRCodeField256, nil); !z.IsZero() || z.Degree() != 0 {
+		t.Fatal(z)
+	}
+	if z := NewPoly(QRCodeField256, []byte{0, 0}); !z.IsZero() {
+		t.Fatal(z)
+	}
+	if m := NewMonomial(QRCodeField256, 3, 5); m.Degree() != 3 || m.Coefficient(3) != 5 {
+		t.Fatal(m)
+	}
+	if s := p.String(); s != "Poly{[1 0 3]}" {
+		t.Fatal(s)
+	}
+}
+
+func TestPolyArithmetic(t *testing.T) {
+	f := QRCodeField256
+	// (x + 2)(x + 3) = x^2 + x + 6 since 2+3 = 1 and 2*3 = 6.
+	a := NewPoly(f, []byte{1, 2})
+	b := NewPoly(f, []byte{1, 3})
+	p := a.Mul(b)
+	compare(t, []byte{1, 1, 6}, p.Coefficients(), "Mul")
+	compare(t, []byte{0}, a.Add(a).Coefficients(), "Add")
+	compare(t, []byte{1, 1, 4}, p.Add(NewPoly(f, []byte{2})).Coefficients(), "Add")
+	compare(t, []byte{2, 4}, a.MulScalar(2).Coefficients(), "MulScalar")
+	compare(t, []byte{2, 4, 0, 0}, a.MulMonomial(2, 2).Coefficients(), "MulMonomial")
+	if p.Evaluate(2) != 0 || p.Evaluate(3) != 0 || p.Evaluate(0) != 6 || p.Evaluate(1) != 6 {
+		t.Fatal("Evaluate")
+	}
+	q, r := p.Divide(a)
+	compare(t, b.Coefficients(), q.Coefficients(), "Divide quotient")
+	if !r.IsZero() {
+		t.Fatal(r)
+	}
+	q, r = p.Add(getOne(f)).Divide(a)
+	compare(t, b.Coefficients(), q.Coefficients(), "Divide quotient")
+	compare(t, []byte{1}, r.Coefficients(), "Divide remainder")
+}
+
+func TestPolyNegativeDegree(t *testing.T) {
+	for i, f := range []func(){
+		func() { NewMonomial(QRCodeField256, -1, 5) },
+		func() { NewPoly(QRCodeField256, []byte{1, 2}).MulMonomial(-1, 5) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("%d: expected panic", i)
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+func TestPolyDivideByZero(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic")
+		}
+	}()
+	NewPoly(QRCodeField256, []byte{1, 2}).Divide(getZero(QRCodeField256))
+}
+
+func TestPolyGCD(t *testing.T) {
+	f := QRCodeField256
+	a := NewPoly(f, []byte{1, 2})
+	b := NewPoly(f, []byte{1, 3})
+	c := NewPoly(f, []byte{1, 4})
+	g := a.Mul(b).MulScalar(7).GCD(a.Mul(c))
+	compare(t, a.Coefficients(), g.Coefficients(), "GCD")
+	compare(t, []byte{1}, b.GCD(c).Coefficients(), "GCD")
+	if !getZero(f).GCD(getZero(f)).IsZero() {
+		t.Fatal("GCD(0, 0)")
+	}
+}
+
+func TestPolyDerivative(t *testing.T) {
+	f := QRCodeField256
+	// d/dx (5x^4 + 4x^3 + 3x^2 + 2x + 1) = 4x^2 + 2 in characteristic 2.
+	d := NewPoly(f, []byte{5, 4, 3, 2, 1}).Derivative()
+	compare(t, []byte{4, 0, 2}, d.Coefficients(), "Derivative")
+	if !NewPoly(f, []byte{7}).Derivative().IsZero() {
+		t.Fatal("derivative of a constant")
+	}
+}
+
+func TestPolyCompose(t *testing.T) {
+	f := QRCodeField256
+	p := NewPoly(f, []byte{1, 0, 3})
+	q := NewPoly(f, []byte{1, 2})
+	c := p.Compose(q)
+	// (x+2)^2 + 3 = x^2 + 4 + 3 = x^2 + 7.
+	compare(t, []byte{1, 0, 7}, c.Coefficients(), "Compose")
+	for x := 0; x < 256; x++ {
+		if c.Evaluate(byte(x)) != p.Evaluate(q.Evaluate(byte(x))) {
+			t.Fatalf("%d", x)
+		}
+	}
+}
+
+func TestPolyRoots(t *testing.T) {
+	f := QRCodeField256
+	p := NewPoly(f, []byte{1, 3}).Mul(NewPoly(f, []byte{1, 0})).Mul(NewPoly(f, []byte{1, 200}))
+	compare(t, []byte{0, 3, 200}, p.Roots(), "Roots")
+	if NewPoly(f, []byte{1, 0, 1}).Roots()[0] != 1 {
+		t.Fatal("x^2+1 = (x+1)^2")
+	}
+	if getZero(f).Roots() != nil {
+		t.Fatal("zero")
+	}
+}
@@ -0,0 +1,287 @@
+/* Copyright 2012 Marc-Antoine Ruel. Licensed under the Apache License, Version
+2.0 (the "License"); you may not use this file except in compliance with the
+License.  You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0. Unless required by applicable law or
+agreed to in writing, software distributed under the License is distributed on
+an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+or implied. See the License for the specific language governing permissions and
+limitations under the License. */
+
+package rs
+
+import (
+	"fmt"
+)
+
+type primeEncoder struct {
+	f   *PrimeField
+	gen []uint16 // Generator polynomial, without its leading 1 term.
+}
+
+// NewPrimeEncoder generates a Reed-Solomon encoder that can generate c ECC
+// symbols over a PrimeField.
+//
+// The generator polynomial is Π(x - g^(prim*(fcr+i))) for i in [0, c) and
+// the ECC symbols are the negated remainder of data·x^c divided by it, so
+// that the codeword evaluates to zero at every root. The roots can be changed
+// with FirstRoot and RootSpacing.
+//
+// Encode panics if a data symbol is not valid in the field.
+func NewPrimeEncoder(f *PrimeField, c int, opts ...Option) WideEncoder {
+	o := getOptions(f.order(), opts)
+	gen := make([]uint16, 1, c+1)
+	gen[0] = 1
+	for i := 0; i < c; i++ {
+		// gen = gen·(x - root)
+		root := f.exp(o.prim * (o.fcr + i))
+		gen = append(gen, 0)
+		for j := len(gen) - 1; j > 0; j-- {
+			gen[j] = f.sub(gen[j], f.mul(root, gen[j-1]))
+		}
+	}
+	return &primeEncoder{f, gen[1:]}
+}
+
+func (p *primeEncoder) Encode(data []uint16, ecc []uint16) {
+	if len(ecc) != len(p.gen) {
+		panic("rs: invalid ECC length")
+	}
+	if len(ecc) == 0 {
+		return
+	}
+	for i := range ecc {
+		ecc[i] = 0
+	}
+	// Calculate the remainder with a linear feedback shift register.
+	for _, d := range data {
+		if int(d) >= p.f.Size() {
+			panic("rs: invalid symbol for the field")
+		}
+		feedback := p.f.add(d, ecc[0])
+		copy(ecc, ecc[1:])
+		ecc[len(ecc)-1] = 0
+		if feedback != 0 {
+			for j, g := range p.gen {
+				ecc[j] = p.f.sub(ecc[j], p.f.mul(feedback, g))
+			}
+		}
+	}
+	for i, v := range ecc {
+		ecc[i] = p.f.sub(0, v)
+	}
+}
+
+type primeDecoder struct {
+	f *PrimeField
+	o options
+}
+
+// NewPrimeDecoder creates a decoder over a PrimeField.
+//
+// It always uses the Berlekamp-Massey algorithm. The options must match the
+// ones used with NewPrimeEncoder.
+func NewPrimeDecoder(f *PrimeField, opts ...Option) WideDecoder {
+	return &primeDecoder{f, getOptions(f.order(), opts)}
+}
+
+func (d *primeDecoder) Decode(data, ecc []uint16) (int, error) {
+	nbErrors, _, err := d.DecodeErasures(data, ecc, nil)
+	return nbErrors, err
+}
+
+func (d *primeDecoder) DecodeErasures(data, ecc []uint16, erasures []int) (int, int, error) {
+	n := len(data) + len(ecc)
+	R := len(ecc)
+	if n > d.f.order() {
+		return 0, 0, fmt.Errorf("%w: codeword too long: %d > %d", ErrInvalidLength, n, d.f.order())
+	}
+	if len(erasures) > R {
+		return 0, 0, fmt.Errorf("%w: too many erasures: %d > %d", ErrInvalidErasure, len(erasures), R)
+	}
+	received := make([]uint16, n)
+	copy(received, data)
+	copy(received[len(data):], ecc)
+	for i, v := range received {
+		if int(v) >= d.f.Size() {
+			return 0, 0, fmt.Errorf("%w: symbol %d at position %d is out of range for GF(%d)", ErrInvalidSymbol, v, i, d.f.p)
+		}
+	}
+	for i, position := range erasures {
+		if position < 0 || position >= n {
+			return 0, 0, fmt.Errorf("%w: position %d out of range [0, %d)", ErrInvalidErasure, position, n)
+		}
+		for j := 0; j < i; j++ {
+			if erasures[j] == position {
+				return 0, 0, fmt.Errorf("%w: duplicate position %d", ErrInvalidErasure, position)
+			}
+		}
+	}
+	s := make([]uint16, R)
+	if !d.syndromes(received, s) {
+		// Congrats! All the data was perfect.
+		return 0, 0, nil
+	}
+	// The errata locator Λ(x) = Π(1 - X_j·x), stored from the constant term up
+	// like the syndromes. It starts as the erasure locator.
+	lambda := make([]uint16, R+1)
+	lambda[0] = 1
+	for i, position := range erasures {
+		y := d.location(n - 1 - position)
+		for j := i + 1; j > 0; j-- {
+			lambda[j] = d.f.sub(lambda[j], d.f.mul(y, lambda[j-1]))
+		}
+	}
+	l := d.berlekampMassey(s, lambda, len(erasures))
+	for _, v := range lambda[l+1:] {
+		if v != 0 {
+			return 0, 0, fmt.Errorf("%w: errata locator degree exceeds its length %d", ErrKeyEquation, l)
+		}
+	}
+	if lambda[l] == 0 {
+		return 0, 0, fmt.Errorf("%w: errata locator degree does not match its length %d", ErrKeyEquation, l)
+	}
+	nbErrors := l - len(erasures)
+	if 2*nbErrors+len(erasures) > R {
+		return 0, 0, fmt.Errorf("%w: %d errors and %d erasures with %d ECC symbols", ErrTooManyErrors, nbErrors, len(erasures), R)
+	}
+	lambda = lambda[:l+1]
+	// Chien's search, limited to the positions in the codeword.
+	var positions []int
+	var locations []uint16
+	for position := 0; position < n && len(positions) < l; position++ {
+		x := d.location(n - 1 - position)
+		if d.evaluate(lambda, d.f.inv(x)) == 0 {
+			positions = append(positions, position)
+			locations = append(locations, x)
+		}
+	}
+	if len(positions) != l {
+		// The missing roots are outside of the shortened codeword.
+		return 0, 0, fmt.Errorf("%w: error locator has fewer roots in the codeword than its degree %d", ErrMiscorrection, l)
+	}
+	if l == 0 {
+		return 0, 0, fmt.Errorf("%w: no error found while the syndrome is not zero", ErrBadLocation)
+	}
+	found := 0
+	for _, position := range positions {
+		for _, e := range erasures {
+			if e == position {
+				found++
+				break
+			}
+		}
+	}
+	if found != len(erasures) {
+		return 0, 0, fmt.Errorf("%w: erasures are not roots of the errata locator", ErrBadLocation)
+	}
+	// The errata evaluator Ω(x) = S(x)·Λ(x) mod x^R.
+	omega := make([]uint16, R)
+	for k := range omega {
+		v := uint16(0)
+		for j := 0; j <= k && j <= l; j++ {
+			v = d.f.add(v, d.f.mul(lambda[j], s[k-j]))
+		}
+		omega[k] = v
+	}
+	// The formal derivative Λ'(x) = Σ j·Λ_j·x^(j-1).
+	derivative := make([]uint16, l)
+	for j := 1; j <= l; j++ {
+		derivative[j-1] = d.f.mul(uint16(j%d.f.p), lambda[j])
+	}
+	// Forney's formula: e = -X^(1-fcr)·Ω(X^-1)/Λ'(X^-1).
+	nbErasures := 0
+	for i, x := range locations {
+		xInv := d.f.inv(x)
+		denominator := d.evaluate(derivative, xInv)
+		if denominator == 0 {
+			return 0, 0, fmt.Errorf("%w: errata locator has a repeated root", ErrBadLocation)
+		}
+		e := d.f.mul(d.evaluate(omega, xInv), d.f.inv(denominator))
+		e = d.f.sub(0, d.f.mul(e, d.f.exp(mod(d.o.prim*(n-1-positions[i])*(1-d.o.fcr), d.f.order()))))
+		if e != 0 {
+			for _, p := range erasures {
+				if p == positions[i] {
+					nbErasures++
+					break
+				}
+			}
+		}
+		received[positions[i]] = d.f.sub(received[positions[i]], e)
+	}
+	if d.o.recheck && d.syndromes(received, s) {
+		return 0, 0, ErrMiscorrection
+	}
+	copy(data, received)
+	copy(ecc, received[len(data):])
+	return nbErrors, nbErasures, nil
+}
+
+// location returns the error location of the term of x^power, which is
+// g^(prim*power).
+func (d *primeDecoder) location(power int) uint16 {
+	return d.f.exp(d.o.prim * power)
+}
+
+// syndromes calculates the syndromes of received into s, S_i being in s[i].
+//
+// Returns false if all the syndromes are zero, e.g. there is no error.
+func (d *primeDecoder) syndromes(received, s []uint16) bool {
+	found := false
+	for i := range s {
+		x := d.location(d.o.fcr + i)
+		v := uint16(0)
+		for _, c := range received {
+			v = d.f.add(d.f.mul(v, x), c)
+		}
+		s[i] = v
+		if v != 0 {
+			found = true
+		}
+	}
+	return found
+}
+
+// berlekampMassey is the equivalent of the package function berlekampMassey
+// in a field where subtraction differs from addition.
+func (d *primeDecoder) berlekampMassey(s, lambda []uint16, nbErasures int) int {
+	b := make([]uint16, len(lambda))
+	t := make([]uint16, len(lambda))
+	copy(b, lambda)
+	l := nbErasures
+	for r := nbErasures; r < len(s); r++ {
+		// Discrepancy, the coefficient of x^r in Λ(x)·S(x).
+		delta := uint16(0)
+		for j := 0; j <= r; j++ {
+			delta = d.f.add(delta, d.f.mul(lambda[j], s[r-j]))
+		}
+		// b = x·b
+		copy(b[1:], b[:len(b)-1])
+		b[0] = 0
+		if delta == 0 {
+			continue
+		}
+		// t = lambda - delta·b
+		for j := range t {
+			t[j] = d.f.sub(lambda[j], d.f.mul(delta, b[j]))
+		}
+		if 2*l <= r+nbErasures {
+			l = r + 1 + nbErasures - l
+			inv := d.f.inv(delta)
+			for j := range b {
+				b[j] = d.f.mul(inv, lambda[j])
+			}
+		}
+		copy(lambda, t)
+	}
+	return l
+}
+
+// evaluate evaluates the polynomial with coefficients stored from the
+// constant term up at x.
+func (d *primeDecoder) evaluate(coefficients []uint16, x uint16) uint16 {
+	v := uint16(0)
+	for i := len(coefficients) - 1; i >= 0; i-- {
+		v = d.f.add(d.f.mul(v, x), coefficients[i])
+	}
+	return v
+}
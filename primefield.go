@@ -0,0 +1,94 @@
+/* Copyright 2012 Marc-Antoine Ruel. Licensed under the Apache License, Version
+2.0 (the "License"); you may not use this file except in compliance with the
+License.  You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0. Unless required by applicable law or
+agreed to in writing, software distributed under the License is distributed on
+an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+or implied. See the License for the specific language governing permissions and
+limitations under the License. */
+
+package rs
+
+import (
+	"strconv"
+)
+
+// PrimeField is a Galois field GF(p) with p prime, where each symbol is stored
+// in an uint16. The arithmetic is the integer arithmetic modulo p.
+//
+// Contrary to GF(2^m), addition and subtraction differ; a codeword can
+// contain up to p-1 symbols.
+type PrimeField struct {
+	p    int
+	exps []uint16 // g^i, repeated twice so the sum of two logs can be looked up.
+	logs []uint16 // logs[0] is unused.
+}
+
+// NewPrimeField returns the field GF(p) with generator g, where p is a prime
+// number between 3 and 65521 inclusively.
+//
+// It panics if p is not prime or if g does not generate the whole
+// multiplicative group.
+func NewPrimeField(p int, g uint16) *PrimeField {
+	if p < 3 || p > 65521 {
+		panic("rs: invalid prime: " + strconv.Itoa(p))
+	}
+	for d := 2; d*d <= p; d++ {
+		if p%d == 0 {
+			panic("rs: invalid prime: " + strconv.Itoa(p))
+		}
+	}
+	if g == 0 || int(g) >= p {
+		panic("rs: invalid generator " + strconv.Itoa(int(g)) + " for prime " + strconv.Itoa(p))
+	}
+	f := &PrimeField{p: p, exps: make([]uint16, 2*(p-1)), logs: make([]uint16, p)}
+	x := 1
+	for i := 0; i < p-1; i++ {
+		if x == 1 && i != 0 {
+			panic("rs: invalid generator " + strconv.Itoa(int(g)) + " for prime " + strconv.Itoa(p))
+		}
+		f.exps[i] = uint16(x)
+		f.exps[i+p-1] = uint16(x)
+		f.logs[x] = uint16(i)
+		x = x * int(g) % p
+	}
+	return f
+}
+
+// Size returns the number of elements in the field, p. Valid symbols are in
+// the range [0, Size()).
+func (f *PrimeField) Size() int {
+	return f.p
+}
+
+// order returns the number of non-zero elements in the field.
+func (f *PrimeField) order() int {
+	return f.p - 1
+}
+
+func (f *PrimeField) add(x, y uint16) uint16 {
+	return uint16((int(x) + int(y)) % f.p)
+}
+
+func (f *PrimeField) sub(x, y uint16) uint16 {
+	return uint16((f.p + int(x) - int(y)) % f.p)
+}
+
+// exp returns g^e. e must be positive.
+func (f *PrimeField) exp(e int) uint16 {
+	return f.exps[e%f.order()]
+}
+
+func (f *PrimeField) inv(x uint16) uint16 {
+	if x == 0 {
+		return 0
+	}
+	return f.exps[f.order()-int(f.logs[x])]
+}
+
+func (f *PrimeField) mul(x, y uint16) uint16 {
+	if x == 0 || y == 0 {
+		return 0
+	}
+	return f.exps[int(f.logs[x])+int(f.logs[y])]
+}
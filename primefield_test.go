@@ -0,0 +1,49 @@
+/* Copyright 2012 Marc-Antoine Ruel. Licensed under the Apache License, Version
+2.0 (the "License"); you may not use this file except in compliance with the
+License.  You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0. Unless required by applicable law or
+agreed to in writing, software distributed under the License is distributed on
+an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+or implied. See the License for the specific language governing permissions and
+limitations under the License. */
+
+package rs
+
+import (
+	"testing"
+)
+
+func TestPrimeField(t *testing.T) {
+	f := NewPrimeField(929, 3)
+	if f.Size() != 929 {
+		t.Fatal(f.Size())
+	}
+	for x := 1; x < 929; x++ {
+		if v := f.mul(uint16(x), f.inv(uint16(x))); v != 1 {
+			t.Fatalf("%d·%d = %d", x, f.inv(uint16(x)), v)
+		}
+		if v := f.add(uint16(x), f.sub(0, uint16(x))); v != 0 {
+			t.Fatalf("%d: %d", x, v)
+		}
+	}
+	if v := f.mul(500, 600); int(v) != 500*600%929 {
+		t.Fatal(v)
+	}
+	if v := f.sub(3, 5); v != 927 {
+		t.Fatal(v)
+	}
+	data := []struct {
+		p int
+		g uint16
+	}{{1, 1}, {4, 3}, {929, 0}, {929, 929}, {929, 2}, {65537, 3}}
+	for _, line := range data {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("GF(%d), %d: expected panic", line.p, line.g)
+				}
+			}()
+			NewPrimeField(line.p, line.g)
+		}()
+	}
+}
@@ -0,0 +1,237 @@
+/* Copyright 2012 Marc-Antoine Ruel. Licensed under the Apache License, Version
+2.0 (the "License"); you may not use this file except in compliance with the
+License.  You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0. Unless required by applicable law or
+agreed to in writing, software distributed under the License is distributed on
+an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+or implied. See the License for the specific language governing permissions and
+limitations under the License. */
+
+package rs
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// QRLevel is the error correction level of a QR code.
+type QRLevel int
+
+const (
+	// QRLevelL recovers about 7% of the codewords.
+	QRLevelL QRLevel = iota
+	// QRLevelM recovers about 15% of the codewords.
+	QRLevelM
+	// QRLevelQ recovers about 25% of the codewords.
+	QRLevelQ
+	// QRLevelH recovers about 30% of the codewords.
+	QRLevelH
+)
+
+func (l QRLevel) String() string {
+	if l >= QRLevelL && l <= QRLevelH {
+		return "LMQH"[l : l+1]
+	}
+	return "QRLevel(" + strconv.Itoa(int(l)) + ")"
+}
+
+// QRCodec implements the block structure of a QR code version and error
+// correction level, as defined in ISO 18004, section 8.5.
+//
+// The data codewords are split into blocks, the blocks of the first group
+// having one data codeword less than the blocks of the second group. Every
+// block has the same number of ECC codewords. The final sequence interleaves
+// the data codewords of all the blocks, followed by their interleaved ECC
+// codewords.
+type QRCodec struct {
+	version int
+	level   QRLevel
+	total   int // Total number of codewords.
+	blocks  int
+	ecc     int // ECC codewords per block.
+	e       Encoder
+	d       Decoder
+}
+
+// NewQRCodec returns the QRCodec for the version between 1 and 40 inclusively
+// and the level.
+func NewQRCodec(version int, level QRLevel) *QRCodec {
+	if version < 1 || version > 40 || level < QRLevelL || level > QRLevelH {
+		panic(fmt.Sprintf("rs: invalid QR code version %d-%s", version, level))
+	}
+	v := &qrVersions[version-1]
+	l := v.levels[level]
+	return &QRCodec{
+		version: version,
+		level:   level,
+		total:   v.total,
+		blocks:  l.blocks,
+		ecc:     l.ecc,
+		e:       NewEncoder(QRCodeField256, l.ecc),
+		d:       NewDecoder(QRCodeField256),
+	}
+}
+
+// Len returns the total number of codewords.
+func (q *QRCodec) Len() int {
+	return q.total
+}
+
+// DataLen returns the number of data codewords.
+func (q *QRCodec) DataLen() int {
+	return q.total - q.blocks*q.ecc
+}
+
+// Blocks returns the number of blocks.
+func (q *QRCodec) Blocks() int {
+	return q.blocks
+}
+
+// ECCPerBlock returns the number of ECC codewords of each block.
+func (q *QRCodec) ECCPerBlock() int {
+	return q.ecc
+}
+
+// BlockDataLen returns the number of data codewords of block i.
+func (q *QRCodec) BlockDataLen(i int) int {
+	n := q.DataLen() / q.blocks
+	if i >= q.blocks-q.DataLen()%q.blocks {
+		n++
+	}
+	return n
+}
+
+// Encode returns the final sequence of Len() codewords of the DataLen()
+// data codewords.
+func (q *QRCodec) Encode(data []byte) ([]byte, error) {
+	if len(data) != q.DataLen() {
+		return nil, fmt.Errorf("%w: expected %d data codewords for QR code %d-%s, got %d", ErrInvalidLength, q.DataLen(), q.version, q.level, len(data))
+	}
+	out := make([]byte, q.total)
+	ecc := make([]byte, q.ecc)
+	start := 0
+	for b := 0; b < q.blocks; b++ {
+		block := data[start : start+q.BlockDataLen(b)]
+		start += len(block)
+		q.e.Encode(block, ecc)
+		q.scatter(out, block, ecc, b)
+	}
+	return out, nil
+}
+
+// Decode corrects the final sequence of Len() codewords and returns the
+// DataLen() data codewords. codewords is not modified.
+//
+// Returns the number of errors corrected in each block. All the blocks are
+// processed even if some fail, in which case their count is -1 and a
+// *BlockError for the first failed block is returned along the data.
+func (q *QRCodec) Decode(codewords []byte) ([]byte, []int, error) {
+	if len(codewords) != q.total {
+		return nil, nil, fmt.Errorf("%w: expected %d codewords for QR code %d-%s, got %d", ErrInvalidLength, q.total, q.version, q.level, len(codewords))
+	}
+	data := make([]byte, q.DataLen())
+	ecc := make([]byte, q.ecc)
+	counts := make([]int, q.blocks)
+	var err error
+	start := 0
+	for b := range counts {
+		block := data[start : start+q.BlockDataLen(b)]
+		start += len(block)
+		q.gather(block, ecc, codewords, b)
+		n, err2 := q.d.Decode(block, ecc)
+		if err2 != nil {
+			n = -1
+			if err == nil {
+				err = &BlockError{b, err2}
+			}
+		}
+		counts[b] = n
+	}
+	return data, counts, err
+}
+
+// scatter writes the data and ecc codewords of block b at their position in
+// the final sequence.
+func (q *QRCodec) scatter(out, data, ecc []byte, b int) {
+	for i, v := range data {
+		out[q.dataPos(i, b)] = v
+	}
+	for i, v := range ecc {
+		out[q.DataLen()+i*q.blocks+b] = v
+	}
+}
+
+// gather is the reverse of scatter.
+func (q *QRCodec) gather(data, ecc, in []byte, b int) {
+	for i := range data {
+		data[i] = in[q.dataPos(i, b)]
+	}
+	for i := range ecc {
+		ecc[i] = in[q.DataLen()+i*q.blocks+b]
+	}
+}
+
+// dataPos returns the position of the data codeword i of block b in the final
+// sequence. The last data codeword of the blocks of the second group is after
+// all the other data codewords.
+func (q *QRCodec) dataPos(i, b int) int {
+	short := q.DataLen() / q.blocks
+	if i < short {
+		return i*q.blocks + b
+	}
+	return short*q.blocks + b - (q.blocks - q.DataLen()%q.blocks)
+}
+
+type qrLevel struct {
+	blocks int
+	ecc    int // ECC codewords per block.
+}
+
+type qrVersion struct {
+	total  int // Total number of codewords.
+	levels [4]qrLevel
+}
+
+// qrVersions is ISO 18004 table 9, indexed by version-1.
+var qrVersions = [40]qrVersion{
+	{26, [4]qrLevel{{1, 7}, {1, 10}, {1, 13}, {1, 17}}},        // 1
+	{44, [4]qrLevel{{1, 10}, {1, 16}, {1, 22}, {1, 28}}},       // 2
+	{70, [4]qrLevel{{1, 15}, {1, 26}, {2, 18}, {2, 22}}},       // 3
+	{100, [4]qrLevel{{1, 20}, {2, 18}, {2, 26}, {4, 16}}},      // 4
+	{134, [4]qrLevel{{1, 26}, {2, 24}, {4, 18}, {4, 22}}},      // 5
+	{172, [4]qrLevel{{2, 18}, {4, 16}, {4, 24}, {4, 28}}},      // 6
+	{196, [4]qrLevel{{2, 20}, {4, 18}, {6, 18}, {5, 26}}},      // 7
+	{242, [4]qrLevel{{2, 24}, {4, 22}, {6, 22}, {6, 26}}},      // 8
+	{292, [4]qrLevel{{2, 30}, {5, 22}, {8, 20}, {8, 24}}},      // 9
+	{346, [4]qrLevel{{4, 18}, {5, 26}, {8, 24}, {8, 28}}},      // 10
+	{404, [4]qrLevel{{4, 20}, {5, 30}, {8, 28}, {11, 24}}},     // 11
+	{466, [4]qrLevel{{4, 24}, {8, 22}, {10, 26}, {11, 28}}},    // 12
+	{532, [4]qrLevel{{4, 26}, {9, 22}, {12, 24}, {16, 22}}},    // 13
+	{581, [4]qrLevel{{4, 30}, {9, 24}, {16, 20}, {16, 24}}},    // 14
+	{655, [4]qrLevel{{6, 22}, {10, 24}, {12, 30}, {18, 24}}},   // 15
+	{733, [4]qrLevel{{6, 24}, {10, 28}, {17, 24}, {16, 30}}},   // 16
+	{815, [4]qrLevel{{6, 28}, {11, 28}, {16, 28}, {19, 28}}},   // 17
+	{901, [4]qrLevel{{6, 30}, {13, 26}, {18, 28}, {21, 28}}},   // 18
+	{991, [4]qrLevel{{7, 28}, {14, 26}, {21, 26}, {25, 26}}},   // 19
+	{1085, [4]qrLevel{{8, 28}, {16, 26}, {20, 30}, {25, 28}}},  // 20
+	{1156, [4]qrLevel{{8, 28}, {17, 26}, {23, 28}, {25, 30}}},  // 21
+	{1258, [4]qrLevel{{9, 28}, {17, 28}, {23, 30}, {34, 24}}},  // 22
+	{1364, [4]qrLevel{{9, 30}, {18, 28}, {25, 30}, {30, 30}}},  // 23
+	{1474, [4]qrLevel{{10, 30}, {20, 28}, {27, 30}, {32, 30}}}, // 24
+	{1588, [4]qrLevel{{12, 26}, {21, 28}, {29, 30}, {35, 30}}}, // 25
+	{1706, [4]qrLevel{{12, 28}, {23, 28}, {34, 28}, {37, 30}}}, // 26
+	{1828, [4]qrLevel{{12, 30}, {25, 28}, {34, 30}, {40, 30}}}, // 27
+	{1921, [4]qrLevel{{13, 30}, {26, 28}, {35, 30}, {42, 30}}}, // 28
+	{2051, [4]qrLevel{{14, 30}, {28, 28}, {38, 30}, {45, 30}}}, // 29
+	{2185, [4]qrLevel{{15, 30}, {29, 28}, {40, 30}, {48, 30}}}, // 30
+	{2323, [4]qrLevel{{16, 30}, {31, 28}, {43, 30}, {51, 30}}}, // 31
+	{2465, [4]qrLevel{{17, 30}, {33, 28}, {45, 30}, {54, 30}}}, // 32
+	{2611, [4]qrLevel{{18, 30}, {35, 28}, {48, 30}, {57, 30}}}, // 33
+	{2761, [4]qrLevel{{19, 30}, {37, 28}, {51, 30}, {60, 30}}}, // 34
+	{2876, [4]qrLevel{{19, 30}, {38, 28}, {53, 30}, {63, 30}}}, // 35
+	{3034, [4]qrLevel{{20, 30}, {40, 28}, {56, 30}, {66, 30}}}, // 36
+	{3196, [4]qrLevel{{21, 30}, {43, 28}, {59, 30}, {70, 30}}}, // 37
+	{3362, [4]qrLevel{{22, 30}, {45, 28}, {62, 30}, {74, 30}}}, // 38
+	{3532, [4]qrLevel{{24, 30}, {47, 28}, {65, 30}, {77, 30}}}, // 39
+	{3706, [4]qrLevel{{25, 30}, {49, 28}, {68, 30}, {81, 30}}}, // 40
+}
@@ -0,0 +1,143 @@
+/* Copyright 2012 Marc-Antoine Ruel. Licensed under the Apache License, Version
+2.0 (the "License"); you may not use this file except in compliance with the
+License.  You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0. Unless required by applicable law or
+agreed to in writing, software distributed under the License is distributed on
+an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+or implied. See the License for the specific language governing permissions and
+limitations under the License. */
+
+package rs
+
+import (
+	"errors"
+	"testing"
+
+	"rsc.io/qr/coding"
+)
+
+// Tests the 1-M example of ISO 18004, Annex I.
+func TestQRCodecISO18004(t *testing.T) {
+	q := NewQRCodec(1, QRLevelM)
+	if q.Len() != 26 || q.DataLen() != 16 || q.Blocks() != 1 || q.ECCPerBlock() != 10 {
+		t.Fatal(q.Len(), q.DataLen(), q.Blocks(), q.ECCPerBlock())
+	}
+	out, err := q.Encode(QRCodeTestData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	compare(t, out, getcomplete(), "Codewords differ")
+}
+
+// Compares the block structure with the one of rsc.io/qr/coding, which
+// concatenates the blocks instead of interleaving them.
+func TestQRCodecVersions(t *testing.T) {
+	for v := 1; v <= 40; v++ {
+		for l := QRLevelL; l <= QRLevelH; l++ {
+			q := NewQRCodec(v, l)
+			nd := coding.Version(v).DataBytes(coding.Level(l))
+			if q.DataLen() != nd {
+				t.Fatalf("%d-%s: %d != %d", v, l, q.DataLen(), nd)
+			}
+			data := make([]byte, nd)
+			for i := range data {
+				data[i] = Rand128[(i*5+v)%len(Rand128)]
+			}
+			var b coding.Bits
+			b.Append(data)
+			b.AddCheckBytes(coding.Version(v), coding.Level(l))
+			ref := b.Bytes()
+			// Interleave the reference.
+			var blocks, eccs [][]byte
+			for i := 0; i < q.Blocks(); i++ {
+				blocks = append(blocks, data[:q.BlockDataLen(i)])
+				data = data[q.BlockDataLen(i):]
+				eccs = append(eccs, ref[nd+i*q.ECCPerBlock():nd+(i+1)*q.ECCPerBlock()])
+			}
+			var expected []byte
+			for i := 0; i <= q.BlockDataLen(q.Blocks()-1); i++ {
+				for _, block := range blocks {
+					if i < len(block) {
+						expected = append(expected, block[i])
+					}
+				}
+			}
+			for i := 0; i < q.ECCPerBlock(); i++ {
+				for _, ecc := range eccs {
+					expected = append(expected, ecc[i])
+				}
+			}
+			out, err := q.Encode(ref[:nd])
+			if err != nil {
+				t.Fatal(err)
+			}
+			compare(t, out, expected, "Codewords differ")
+			got, counts, err := q.Decode(out)
+			if err != nil || len(counts) != q.Blocks() {
+				t.Fatal(counts, err)
+			}
+			compare(t, got, ref[:nd], "Data differs")
+		}
+	}
+}
+
+func TestQRCodecDecode(t *testing.T) {
+	// 5-Q has 2 blocks of 15 data codewords and 2 blocks of 16, with 18 ECC
+	// codewords each.
+	q := NewQRCodec(5, QRLevelQ)
+	if q.BlockDataLen(0) != 15 || q.BlockDataLen(3) != 16 {
+		t.Fatal(q.BlockDataLen(0), q.BlockDataLen(3))
+	}
+	data := makecopy(Rand128[:q.DataLen()])
+	out, err := q.Encode(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A burst of 36 codewords is 9 errors per block.
+	for i := 52; i < 88; i++ {
+		out[i] ^= 0xA5
+	}
+	got, counts, err := q.Decode(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if counts[0] != 9 || counts[1] != 9 || counts[2] != 9 || counts[3] != 9 {
+		t.Fatal(counts)
+	}
+	compare(t, got, data, "Data differs")
+	// One more error in the block 1.
+	out[1] ^= 0xA5
+	_, counts, err = q.Decode(out)
+	var blockErr *BlockError
+	if !errors.As(err, &blockErr) || blockErr.Block != 1 || counts[1] != -1 {
+		t.Fatal(counts, err)
+	}
+	if _, err := q.Encode(data[1:]); !errors.Is(err, ErrInvalidLength) {
+		t.Fatal(err)
+	}
+	if _, _, err := q.Decode(out[1:]); !errors.Is(err, ErrInvalidLength) {
+		t.Fatal(err)
+	}
+}
+
+func TestQRCodecInvalid(t *testing.T) {
+	if s := QRLevelQ.String(); s != "Q" {
+		t.Fatal(s)
+	}
+	if s := QRLevel(4).String(); s != "QRLevel(4)" {
+		t.Fatal(s)
+	}
+	for _, line := range []struct {
+		v int
+		l QRLevel
+	}{{0, QRLevelL}, {41, QRLevelL}, {1, QRLevel(4)}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("%d-%s: expected panic", line.v, line.l)
+				}
+			}()
+			NewQRCodec(line.v, line.l)
+		}()
+	}
+}
@@ -0,0 +1,259 @@
+/* Copyright 2012 Marc-Antoine Ruel. Licensed under the Apache License, Version
+2.0 (the "License"); you may not use this file except in compliance with the
+License.  You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0. Unless required by applicable law or
+agreed to in writing, software distributed under the License is distributed on
+an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+or implied. See the License for the specific language governing permissions and
+limitations under the License. */
+
+package rs
+
+import (
+	"errors"
+	"io"
+	"strconv"
+)
+
+// Stream format
+//
+// The stream written by Writer is a sequence of RS(n,k) codewords in
+// QRCodeField256. Each codeword is n bytes long and holds k bytes of data
+// followed by n-k ECC bytes.
+//
+// The stream ends with the trailer, a shortened codeword holding the final m
+// bytes of data, with 0 <= m < k, followed by n-k ECC bytes. Its real length m
+// is given by its size, m+n-k bytes, which is always shorter than a codeword.
+// The trailer is encoded as if its data was prefixed with the byte
+// streamTrailer, which is not written. So the trailer is always present, even
+// when the data is a multiple of k bytes, and a stream truncated at a
+// codeword boundary is detected.
+
+// Writer is an io.Writer that encodes the data written to it as RS(n,k)
+// codewords written to the underlying writer.
+//
+// Close must be called to write the trailer.
+type Writer struct {
+	w   io.Writer
+	c   *Codec
+	buf []byte // Current codeword.
+	m   int    // Number of data bytes in buf.
+	err error
+}
+
+// NewWriter returns a Writer that writes RS(n,k) codewords to w.
+//
+// n must be at most 255 and k between 1 and n-1 inclusively. The options are
+// the same as NewEncoder.
+func NewWriter(w io.Writer, k, n int, opts ...Option) *Writer {
+	return &Writer{w: w, c: NewCodec(QRCodeField256, n, k, opts...), buf: make([]byte, n)}
+}
+
+// Write buffers p and writes every complete codeword.
+func (w *Writer) Write(p []byte) (int, error) {
+	if w.err != nil {
+		return 0, w.err
+	}
+	k := w.c.K()
+	written := 0
+	for len(p) != 0 {
+		l := copy(w.buf[w.m:k], p)
+		w.m += l
+		p = p[l:]
+		if w.m == k {
+			if w.err = w.c.Encode(w.buf[:k], w.buf[k:]); w.err != nil {
+				return written, w.err
+			}
+			if _, w.err = w.w.Write(w.buf); w.err != nil {
+				return written, w.err
+			}
+			w.m = 0
+		}
+		written += l
+	}
+	return written, nil
+}
+
+// Close writes the buffered data in the trailer codeword. It doesn't close
+// the underlying writer.
+func (w *Writer) Close() error {
+	if w.err != nil {
+		if w.err == errClosed {
+			return nil
+		}
+		return w.err
+	}
+	k := w.c.K()
+	t := make([]byte, w.c.N())
+	t[k-1-w.m] = streamTrailer
+	copy(t[k-w.m:], w.buf[:w.m])
+	if w.err = w.c.Encode(t[:k], t[k:]); w.err != nil {
+		return w.err
+	}
+	if _, w.err = w.w.Write(t[k-w.m:]); w.err != nil {
+		return w.err
+	}
+	w.err = errClosed
+	return nil
+}
+
+var errClosed = errors.New("rs: write after Close")
+
+// streamTrailer is the virtual byte preceding the data of the trailer.
+const streamTrailer = 0x01
+
+// FailurePolicy is the behavior of a Reader on codewords that can't be
+// corrected.
+type FailurePolicy int
+
+const (
+	// FailWithError makes Read return a *BlockError. It is the default.
+	FailWithError FailurePolicy = iota
+	// ZeroFill replaces the data of the codeword with zeros.
+	ZeroFill
+	// PassThrough returns the data of the codeword as received.
+	PassThrough
+)
+
+func (f FailurePolicy) String() string {
+	switch f {
+	case FailWithError:
+		return "FailWithError"
+	case ZeroFill:
+		return "ZeroFill"
+	case PassThrough:
+		return "PassThrough"
+	default:
+		return "FailurePolicy(" + strconv.Itoa(int(f)) + ")"
+	}
+}
+
+// ReaderStats are the running statistics of a Reader.
+type ReaderStats struct {
+	// Blocks is the number of codewords read.
+	Blocks int
+	// Corrected is the number of bytes corrected.
+	Corrected int
+	// Failed is the number of codewords that couldn't be corrected.
+	Failed int
+}
+
+// Reader is an io.Reader that decodes the RS(n,k) codewords written by Writer
+// and returns the corrected data.
+type Reader struct {
+	// Policy is the behavior on codewords that can't be corrected. It must be
+	// set before the first Read.
+	//
+	// A final block shorter than a codeword that can't be corrected is either
+	// a corrupted trailer or a truncated codeword, so Read returns
+	// io.ErrUnexpectedEOF whatever the Policy.
+	Policy FailurePolicy
+
+	r       io.Reader
+	c       *Codec
+	buf     []byte // Current codeword.
+	trailer []byte // The trailer, with its virtual bytes.
+	pending []byte // Data not yet returned.
+	stats   ReaderStats
+	err     error
+}
+
+// NewReader returns a Reader that reads RS(n,k) codewords from r. The stream
+// must end after the trailer.
+//
+// n must be at most 255 and k between 1 and n-1 inclusively. The options are
+// the same as NewDecoder.
+func NewReader(r io.Reader, k, n int, opts ...Option) *Reader {
+	return &Reader{r: r, c: NewCodec(QRCodeField256, n, k, opts...), buf: make([]byte, n), trailer: make([]byte, n)}
+}
+
+// Stats returns the statistics of the codewords read so far.
+func (r *Reader) Stats() ReaderStats {
+	return r.stats
+}
+
+// Read returns the corrected data.
+//
+// It returns io.EOF after the data of the trailer and io.ErrUnexpectedEOF if
+// the stream ends before the trailer.
+func (r *Reader) Read(p []byte) (int, error) {
+	for len(r.pending) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		r.err = r.fill()
+	}
+	n := copy(p, r.pending)
+	r.pending = r.pending[n:]
+	return n, nil
+}
+
+// fill reads and decodes the next codeword into pending.
+//
+// Returns io.EOF once the trailer was read.
+func (r *Reader) fill() error {
+	l, err := io.ReadFull(r.r, r.buf)
+	if err == io.ErrUnexpectedEOF {
+		return r.fillTrailer(l)
+	}
+	if err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return err
+	}
+	k := r.c.K()
+	data := r.buf[:k]
+	r.stats.Blocks++
+	n, err := r.c.Decode(data, r.buf[k:])
+	if err != nil {
+		r.stats.Failed++
+		if r.Policy != ZeroFill && r.Policy != PassThrough {
+			return &BlockError{r.stats.Blocks - 1, err}
+		}
+		if r.Policy == ZeroFill {
+			for i := range data {
+				data[i] = 0
+			}
+		}
+	} else {
+		r.stats.Corrected += n
+	}
+	r.pending = data
+	return nil
+}
+
+// fillTrailer decodes the l bytes at the end of the stream as the trailer.
+func (r *Reader) fillTrailer(l int) error {
+	k := r.c.K()
+	m := l - r.c.ECCLen()
+	if m < 0 {
+		return io.ErrUnexpectedEOF
+	}
+	r.stats.Blocks++
+	t := r.trailer
+	for i := range t[:k-1-m] {
+		t[i] = 0
+	}
+	t[k-1-m] = streamTrailer
+	copy(t[k-m:], r.buf[:l])
+	n, err := r.c.Decode(t[:k], t[k:])
+	if err == nil {
+		// The virtual bytes must not have been corrected.
+		for _, v := range t[:k-1-m] {
+			if v != 0 {
+				err = ErrMiscorrection
+			}
+		}
+		if t[k-1-m] != streamTrailer {
+			err = ErrMiscorrection
+		}
+	}
+	if err != nil {
+		r.stats.Failed++
+		return io.ErrUnexpectedEOF
+	}
+	r.stats.Corrected += n
+	r.pending = t[k-m : k]
+	return io.EOF
+}
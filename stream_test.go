@@ -0,0 +1,234 @@
+/* Copyright 2012 Marc-Antoine Ruel. Licensed under the Apache License, Version
+2.0 (the "License"); you may not use this file except in compliance with the
+License.  You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0. Unless required by applicable law or
+agreed to in writing, software distributed under the License is distributed on
+an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+or implied. See the License for the specific language governing permissions and
+limitations under the License. */
+
+package rs
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"testing"
+)
+
+func TestWriter(t *testing.T) {
+	c := NewCodec(QRCodeField256, 26, 16)
+	for _, size := range []int{0, 1, 15, 16, 17, 100, 160} {
+		data := make([]byte, size)
+		for i := range data {
+			data[i] = Rand128[i%len(Rand128)]
+		}
+		out := &bytes.Buffer{}
+		w := NewWriter(out, 16, 26)
+		// Write in irregular chunks.
+		for i := 0; i < size; {
+			e := i + 1 + i%7
+			if e > size {
+				e = size
+			}
+			if n, err := w.Write(data[i:e]); n != e-i || err != nil {
+				t.Fatal(n, err)
+			}
+			i = e
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		// Each codeword holds 16 bytes of data, the trailer the remainder.
+		if out.Len() != size/16*26+size%16+10 {
+			t.Fatalf("%d: got %d bytes", size, out.Len())
+		}
+		var got []byte
+		b := out.Bytes()
+		for ; len(b) >= 26; b = b[26:] {
+			if ok, err := c.Verify(b[:16], b[16:26]); !ok || err != nil {
+				t.Fatal(ok, err)
+			}
+			got = append(got, b[:16]...)
+		}
+		// The trailer is encoded with its virtual bytes.
+		m := len(b) - 10
+		trailer := make([]byte, 26)
+		trailer[15-m] = 1
+		copy(trailer[16-m:], b)
+		if ok, err := c.Verify(trailer[:16], trailer[16:]); !ok || err != nil {
+			t.Fatal(ok, err)
+		}
+		got = append(got, b[:m]...)
+		if !bytes.Equal(got, data) {
+			t.Fatalf("%d: data differs", size)
+		}
+		if _, err := w.Write([]byte{1}); err == nil {
+			t.Fatal("expected error")
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// encodeStream returns size bytes of data and its encoding by Writer.
+func encodeStream(t *testing.T, size int) ([]byte, []byte) {
+	data := make([]byte, size)
+	for i := range data {
+		data[i] = Rand128[(i*3)%len(Rand128)]
+	}
+	out := &bytes.Buffer{}
+	w := NewWriter(out, 16, 26)
+	if _, err := w.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return data, out.Bytes()
+}
+
+func TestReader(t *testing.T) {
+	for _, size := range []int{0, 1, 15, 16, 17, 100, 160} {
+		data, stream := encodeStream(t, size)
+		corrected := 0
+		for i := 0; i < len(stream); i += 26 {
+			n := rand.Intn(6)
+			if i+26 > len(stream) {
+				corrupt(stream[i:], n)
+			} else {
+				corrupt(stream[i:i+26], n)
+			}
+			corrected += n
+		}
+		r := NewReader(bytes.NewReader(stream), 16, 26)
+		got, err := ioutil.ReadAll(r)
+		if err != nil {
+			t.Fatalf("%d: %v", size, err)
+		}
+		if !bytes.Equal(got, data) {
+			t.Fatalf("%d: data differs", size)
+		}
+		if s := r.Stats(); s.Blocks != len(stream)/26+1 || s.Corrected != corrected || s.Failed != 0 {
+			t.Fatalf("%d: %+v", size, s)
+		}
+	}
+}
+
+func TestReaderPolicy(t *testing.T) {
+	data, stream := encodeStream(t, 40)
+	// Too many errors in the second codeword.
+	corrupt(stream[26:52], 8)
+	r := NewReader(bytes.NewReader(makecopy(stream)), 16, 26)
+	got, err := ioutil.ReadAll(r)
+	var blockErr *BlockError
+	if !errors.As(err, &blockErr) || blockErr.Block != 1 {
+		t.Fatal(err)
+	}
+	compare(t, got, data[:16], "Data differs")
+
+	r = NewReader(bytes.NewReader(makecopy(stream)), 16, 26)
+	r.Policy = ZeroFill
+	if got, err = ioutil.ReadAll(r); err != nil {
+		t.Fatal(err)
+	}
+	expected := makecopy(data)
+	copy(expected[16:32], make([]byte, 16))
+	compare(t, got, expected, "Data differs")
+
+	r = NewReader(bytes.NewReader(makecopy(stream)), 16, 26)
+	r.Policy = PassThrough
+	if got, err = ioutil.ReadAll(r); err != nil {
+		t.Fatal(err)
+	}
+	expected = makecopy(data)
+	copy(expected[16:32], stream[26:42])
+	compare(t, got, expected, "Data differs")
+	if s := r.Stats(); s.Blocks != 3 || s.Failed != 1 {
+		t.Fatalf("%+v", s)
+	}
+	if s := PassThrough.String(); s != "PassThrough" {
+		t.Fatal(s)
+	}
+	if s := FailurePolicy(42).String(); s != "FailurePolicy(42)" {
+		t.Fatal(s)
+	}
+}
+
+func TestReaderTruncated(t *testing.T) {
+	data, stream := encodeStream(t, 40)
+	// Every truncation is reported, including at a codeword boundary.
+	for l := 0; l < len(stream); l++ {
+		got, err := ioutil.ReadAll(NewReader(bytes.NewReader(stream[:l]), 16, 26))
+		if err != io.ErrUnexpectedEOF {
+			t.Fatalf("%d: %v", l, err)
+		}
+		compare(t, got, data[:l/26*16], "Data differs")
+	}
+	text := bytes.Repeat([]byte("The quick brown fox jumps over the lazy dog. "), 25)[:1100]
+	out := &bytes.Buffer{}
+	w := NewWriter(out, 223, 255)
+	if _, err := w.Write(text); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	for c := 0; c < out.Len()/255; c++ {
+		got, err := ioutil.ReadAll(NewReader(bytes.NewReader(out.Bytes()[:c*255]), 223, 255))
+		if err != io.ErrUnexpectedEOF {
+			t.Fatalf("%d codewords: %v", c, err)
+		}
+		compare(t, got, text[:c*223], "Data differs")
+	}
+	// Without the virtual byte, the start of a codeword of zeros would be a
+	// valid empty trailer.
+	out.Reset()
+	w = NewWriter(out, 16, 26)
+	if _, err := w.Write(make([]byte, 32)); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	for l := 10; l < 26; l++ {
+		if _, err := ioutil.ReadAll(NewReader(bytes.NewReader(out.Bytes()[:26+l]), 16, 26)); err != io.ErrUnexpectedEOF {
+			t.Fatalf("%d: %v", l, err)
+		}
+	}
+	// A trailer that can't be corrected may be a truncated codeword.
+	stream = makecopy(stream)
+	corrupt(stream[52:], 6)
+	r := NewReader(bytes.NewReader(stream), 16, 26)
+	r.Policy = PassThrough
+	if _, err := ioutil.ReadAll(r); err != io.ErrUnexpectedEOF {
+		t.Fatal(err)
+	}
+}
+
+func TestWriterError(t *testing.T) {
+	w := NewWriter(failingWriter{}, 16, 26)
+	if n, err := w.Write(make([]byte, 20)); n != 0 || err != errFailing {
+		t.Fatal(n, err)
+	}
+	if err := w.Close(); err != errFailing {
+		t.Fatal(err)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic")
+		}
+	}()
+	NewWriter(&bytes.Buffer{}, 0, 26)
+}
+
+var errFailing = errors.New("failing")
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errFailing
+}
@@ -0,0 +1,239 @@
+/* Copyright 2012 Marc-Antoine Ruel. Licensed under the Apache License, Version
+2.0 (the "License"); you may not use this file except in compliance with the
+License.  You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0. Unless required by applicable law or
+agreed to in writing, software distributed under the License is distributed on
+an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+or implied. See the License for the specific language governing permissions and
+limitations under the License. */
+
+package rs
+
+import (
+	"errors"
+	"fmt"
+)
+
+// WideDecoder can error correct data with the corresponding ECC codes over a
+// WideField or a PrimeField.
+//
+// It implements the same algorithm as Decoder.
+type WideDecoder interface {
+	// Decode corrects errors in-place in the input. See Decoder.Decode.
+	Decode(data, ecc []uint16) (int, error)
+	// DecodeErasures corrects errors and erasures in-place in the input. See
+	// Decoder.DecodeErasures.
+	DecodeErasures(data, ecc []uint16, erasures []int) (nbErrors, nbErasures int, err error)
+}
+
+type wideDecoder struct {
+	f *WideField
+	o options
+}
+
+// NewWideDecoder creates a decoder in the defined field.
+//
+// The options must match the ones used with NewWideEncoder.
+func NewWideDecoder(f *WideField, opts ...Option) WideDecoder {
+	return &wideDecoder{f, getOptions(f.order(), opts)}
+}
+
+func (d *wideDecoder) Decode(data, ecc []uint16) (int, error) {
+	nbErrors, _, err := d.DecodeErasures(data, ecc, nil)
+	return nbErrors, err
+}
+
+func (d *wideDecoder) DecodeErasures(data, ecc []uint16, erasures []int) (int, int, error) {
+	n := len(data) + len(ecc)
+	if n > d.f.order() {
+		return 0, 0, fmt.Errorf("%w: codeword too long: %d > %d", ErrInvalidLength, n, d.f.order())
+	}
+	if len(erasures) > len(ecc) {
+		return 0, 0, fmt.Errorf("%w: too many erasures: %d > %d", ErrInvalidErasure, len(erasures), len(ecc))
+	}
+	received := make([]uint16, n)
+	copy(received, data)
+	copy(received[len(data):], ecc)
+	for i, v := range received {
+		if int(v) >= d.f.Size() {
+			return 0, 0, fmt.Errorf("%w: symbol %d at position %d is out of range for GF(2^%d)", ErrInvalidSymbol, v, i, d.f.bits)
+		}
+	}
+	// Build the erasure locator polynomial Γ(x) = Π(1 + Y_i·x).
+	erasureLocations := make([]uint16, len(erasures))
+	gamma := getWideOne(d.f)
+	for i, position := range erasures {
+		if position < 0 || position >= n {
+			return 0, 0, fmt.Errorf("%w: position %d out of range [0, %d)", ErrInvalidErasure, position, n)
+		}
+		erasureLocations[i] = d.location(n - 1 - position)
+		for j := 0; j < i; j++ {
+			if erasureLocations[j] == erasureLocations[i] {
+				return 0, 0, fmt.Errorf("%w: duplicate position %d", ErrInvalidErasure, position)
+			}
+		}
+		gamma = gamma.mulPoly(&widePoly{d.f, []uint16{erasureLocations[i], 1}})
+	}
+	syndrome := d.syndrome(received, len(ecc))
+	if syndrome == nil {
+		// Congrats! All the data was perfect.
+		return 0, 0, nil
+	}
+	// There was corruption found.
+	if len(erasures) != 0 {
+		syndrome = syndrome.mulPoly(gamma).modMonomial(len(ecc))
+	}
+	sigma, omega, err := d.runEuclideanAlgorithm(buildWideMonomial(d.f, len(ecc), 1), syndrome, len(ecc), len(erasures))
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w: runEuclidean() over %d symbols + %d ECC symbols failed: %s", ErrKeyEquation, len(data), len(ecc), err)
+	}
+	if l := sigma.degree(); 2*l+len(erasures) > len(ecc) {
+		return 0, 0, fmt.Errorf("%w: %d errors and %d erasures with %d ECC symbols", ErrTooManyErrors, l, len(erasures), len(ecc))
+	}
+	errorPositions := d.findErrorPositions(sigma, n)
+	if errorPositions == nil {
+		// The missing roots are outside of the shortened codeword.
+		return 0, 0, fmt.Errorf("%w: error locator has fewer roots in the codeword than its degree %d", ErrMiscorrection, sigma.degree())
+	}
+	if len(errorPositions)+len(erasures) == 0 {
+		return 0, 0, fmt.Errorf("%w: no error found while the syndrome is not zero", ErrBadLocation)
+	}
+	// Past the correction capability, Euclid's algorithm can return an
+	// evaluator that doesn't match the errata locator σ(x)·Γ(x).
+	if l := sigma.degree() + len(erasures); omega.degree() >= l {
+		return 0, 0, fmt.Errorf("%w: errata evaluator degree %d is not lower than the errata locator degree %d", ErrKeyEquation, omega.degree(), l)
+	}
+	positions := append(errorPositions, erasures...)
+	errataLocations := make([]uint16, len(positions))
+	for i, position := range positions {
+		if i < len(errorPositions) {
+			for _, e := range erasures {
+				if e == position {
+					return 0, 0, fmt.Errorf("%w: error location %d is also an erasure", ErrBadLocation, position)
+				}
+			}
+		}
+		errataLocations[i] = d.location(n - 1 - position)
+	}
+	errataMagnitudes := d.findErrorMagnitudes(omega, errataLocations)
+	nbErasures := 0
+	for i, position := range positions {
+		if i >= len(errorPositions) && errataMagnitudes[i] != 0 {
+			nbErasures++
+		}
+		received[position] = d.f.add(received[position], errataMagnitudes[i])
+	}
+	if d.o.recheck && d.syndrome(received, len(ecc)) != nil {
+		return 0, 0, ErrMiscorrection
+	}
+	copy(data, received)
+	copy(ecc, received[len(data):])
+	return len(errorPositions), nbErasures, nil
+}
+
+// location returns the error location of the term of x^power, which is
+// α^(prim*power).
+func (d *wideDecoder) location(power int) uint16 {
+	return d.f.exp(mulmod(d.o.prim, power, d.f.order()))
+}
+
+// syndrome returns the syndrome polynomial of received, or nil if all the
+// syndromes are zero, e.g. there is no error.
+func (d *wideDecoder) syndrome(received []uint16, nbECC int) *widePoly {
+	poly := &widePoly{d.f, received}
+	syndromeCoeffs := make([]uint16, nbECC)
+	noError := true
+	for i := 0; i < nbECC; i++ {
+		eval := poly.evaluateAt(d.location(d.o.fcr + i))
+		syndromeCoeffs[len(syndromeCoeffs)-1-i] = eval
+		if eval != 0 {
+			noError = false
+		}
+	}
+	if noError {
+		return nil
+	}
+	return makeWidePoly(d.f, syndromeCoeffs)
+}
+
+// runEuclideanAlgorithm solves the key equation. See
+// rSDecoder.runEuclideanAlgorithm.
+func (d *wideDecoder) runEuclideanAlgorithm(a, b *widePoly, R, nbErasures int) (sigma *widePoly, omega *widePoly, err error) {
+	// Assume a's degree >= b's
+	if a.degree() < b.degree() {
+		a, b = b, a
+	}
+	rLast := a
+	r := b
+	tLast := getWideZero(d.f)
+	t := getWideOne(d.f)
+
+	// Run Euclidean algorithm until r's degree is less than (R+nbErasures)/2.
+	for i := 0; r.degree() >= (R+nbErasures)/2; i++ {
+		rLastLast := rLast
+		tLastLast := tLast
+		rLast = r
+		tLast = t
+
+		// Divide rLastLast by rLast, with quotient in q and remainder in r.
+		if rLast.isZero() {
+			// Oops, Euclidean algorithm already terminated?
+			return nil, nil, fmt.Errorf("r_{i-1} was zero after %d iteration(s)", i)
+		}
+		r = rLastLast
+		q := getWideZero(d.f)
+		dltInverse := d.f.inv(rLast.getCoefficient(rLast.degree()))
+		for r.degree() >= rLast.degree() && !r.isZero() {
+			degreeDiff := r.degree() - rLast.degree()
+			scale := d.f.mul(r.getCoefficient(r.degree()), dltInverse)
+			q = q.add(buildWideMonomial(d.f, degreeDiff, scale))
+			r = r.add(rLast.mulByMonomial(degreeDiff, scale))
+		}
+		t = q.mulPoly(tLast).add(tLastLast)
+	}
+
+	sigmaTildeAtZero := t.getCoefficient(0)
+	if sigmaTildeAtZero == 0 {
+		return nil, nil, errors.New("sigmaTilde(0) was zero")
+	}
+
+	inverse := d.f.inv(sigmaTildeAtZero)
+	return t.mulScalar(inverse), r.mulScalar(inverse), nil
+}
+
+// findErrorPositions is Chien's search, limited to the n positions of the
+// codeword since the field can be much larger than a shortened codeword.
+func (d *wideDecoder) findErrorPositions(errorLocator *widePoly, n int) []int {
+	numErrors := errorLocator.degree()
+	result := make([]int, 0, numErrors)
+	for position := 0; position < n && len(result) < numErrors; position++ {
+		if errorLocator.evaluateAt(d.f.inv(d.location(n-1-position))) == 0 {
+			result = append(result, position)
+		}
+	}
+	if len(result) != numErrors {
+		return nil
+	}
+	return result
+}
+
+// This is directly applying Forney's Formula.
+func (d *wideDecoder) findErrorMagnitudes(errorEvaluator *widePoly, errorLocations []uint16) []uint16 {
+	s := len(errorLocations)
+	result := make([]uint16, s)
+	for i := 0; i < s; i++ {
+		xiInverse := d.f.inv(errorLocations[i])
+		denominator := uint16(1)
+		for j := 0; j < s; j++ {
+			if i != j {
+				denominator = d.f.mul(denominator, d.f.add(1, d.f.mul(errorLocations[j], xiInverse)))
+			}
+		}
+		result[i] = d.f.mul(errorEvaluator.evaluateAt(xiInverse), d.f.inv(denominator))
+		if d.o.fcr != 0 {
+			// The syndromes start at X^fcr instead of X^0.
+			result[i] = d.f.mul(result[i], d.f.exp(mulmod(d.f.log(xiInverse), d.o.fcr, d.f.order())))
+		}
+	}
+	return result
+}
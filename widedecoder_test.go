@@ -0,0 +1,196 @@
+/* Copyright 2012 Marc-Antoine Ruel. Licensed under the Apache License, Version
+2.0 (the "License"); you may not use this file except in compliance with the
+License.  You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0. Unless required by applicable law or
+agreed to in writing, software distributed under the License is distributed on
+an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+or implied. See the License for the specific language governing permissions and
+limitations under the License. */
+
+package rs
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestWideDecode(t *testing.T) {
+	for _, line := range []struct{ n, c int }{{10, 4}, {1000, 20}, {60000, 64}} {
+		golden := makeWideData(line.n)
+		goldenECC := make([]uint16, line.c)
+		NewWideEncoder(Field65536(), line.c).Encode(golden, goldenECC)
+		d := NewWideDecoder(Field65536())
+		for nbErrors := 0; nbErrors <= line.c/2; nbErrors += 1 + line.c/8 {
+			data := append([]uint16{}, golden...)
+			ecc := append([]uint16{}, goldenECC...)
+			corruptWide(data, ecc, rand.Perm(line.n + line.c)[:nbErrors])
+			if n, err := d.Decode(data, ecc); err != nil || n != nbErrors {
+				t.Fatalf("%d+%d: expected %d errors, got %d: %v", line.n, line.c, nbErrors, n, err)
+			}
+			if !reflect.DeepEqual(data, golden) || !reflect.DeepEqual(ecc, goldenECC) {
+				t.Fatalf("%d+%d: not corrected", line.n, line.c)
+			}
+		}
+	}
+}
+
+func TestWideDecodeErasures(t *testing.T) {
+	golden := makeWideData(300)
+	goldenECC := make([]uint16, 16)
+	NewWideEncoder(Field65536(), len(goldenECC)).Encode(golden, goldenECC)
+	d := NewWideDecoder(Field65536())
+	for nbErasures := 0; nbErasures <= len(goldenECC); nbErasures++ {
+		nbErrors := (len(goldenECC) - nbErasures) / 2
+		data := append([]uint16{}, golden...)
+		ecc := append([]uint16{}, goldenECC...)
+		perm := rand.Perm(len(data) + len(ecc))
+		corruptWide(data, ecc, perm[:nbErasures+nbErrors])
+		n, m, err := d.DecodeErasures(data, ecc, perm[:nbErasures])
+		if err != nil || n != nbErrors || m != nbErasures {
+			t.Fatalf("expected %d errors, %d erasures; got %d, %d: %v", nbErrors, nbErasures, n, m, err)
+		}
+		if !reflect.DeepEqual(data, golden) || !reflect.DeepEqual(ecc, goldenECC) {
+			t.Fatal("not corrected")
+		}
+	}
+}
+
+func TestWideDecodeSmallFields(t *testing.T) {
+	for _, poly := range []int{0xB, 0x13, 0x43, 0x409, 0x1069} {
+		f := NewWideField(poly, 2)
+		// Use full length codewords.
+		c := 2 + f.order()/8
+		if c > 32 {
+			c = 32
+		}
+		golden := make([]uint16, f.order()-c)
+		for i := range golden {
+			golden[i] = uint16(rand.Intn(f.Size()))
+		}
+		goldenECC := make([]uint16, c)
+		NewWideEncoder(f, c).Encode(golden, goldenECC)
+		for nbErrors := 0; nbErrors <= c/2; nbErrors++ {
+			data := append([]uint16{}, golden...)
+			ecc := append([]uint16{}, goldenECC...)
+			for _, p := range rand.Perm(len(data) + len(ecc))[:nbErrors] {
+				v := uint16(1 + rand.Intn(f.order()))
+				if p < len(data) {
+					data[p] ^= v
+				} else {
+					ecc[p-len(data)] ^= v
+				}
+			}
+			if n, err := NewWideDecoder(f).Decode(data, ecc); err != nil || n != nbErrors {
+				t.Fatalf("GF(2^%d): expected %d errors, got %d: %v", f.Bits(), nbErrors, n, err)
+			}
+			if !reflect.DeepEqual(data, golden) || !reflect.DeepEqual(ecc, goldenECC) {
+				t.Fatalf("GF(2^%d): not corrected", f.Bits())
+			}
+		}
+	}
+}
+
+func TestWideDecodeRoots(t *testing.T) {
+	f := NewWideField(0x409, 2)
+	opts := []Option{FirstRoot(1), RootSpacing(7), CheckMiscorrection(true)}
+	golden := makeWideData(200)
+	for i := range golden {
+		golden[i] &= 0x3FF
+	}
+	goldenECC := make([]uint16, 12)
+	NewWideEncoder(f, len(goldenECC), opts...).Encode(golden, goldenECC)
+	d := NewWideDecoder(f, opts...)
+	for nbErasures := 0; nbErasures <= len(goldenECC); nbErasures += 2 {
+		nbErrors := (len(goldenECC) - nbErasures) / 2
+		data := append([]uint16{}, golden...)
+		ecc := append([]uint16{}, goldenECC...)
+		perm := rand.Perm(len(data) + len(ecc))
+		for _, p := range perm[:nbErasures+nbErrors] {
+			v := uint16(1 + rand.Intn(f.order()))
+			if p < len(data) {
+				data[p] ^= v
+			} else {
+				ecc[p-len(data)] ^= v
+			}
+		}
+		n, m, err := d.DecodeErasures(data, ecc, perm[:nbErasures])
+		if err != nil || n != nbErrors || m != nbErasures {
+			t.Fatalf("expected %d errors, %d erasures; got %d, %d: %v", nbErrors, nbErasures, n, m, err)
+		}
+		if !reflect.DeepEqual(data, golden) || !reflect.DeepEqual(ecc, goldenECC) {
+			t.Fatal("not corrected")
+		}
+	}
+}
+
+// The products of the roots overflow 32 bits in GF(2^16).
+func TestWideDecodeLargeRoots(t *testing.T) {
+	for _, opts := range [][]Option{
+		{FirstRoot(50000)},
+		{RootSpacing(65534)},
+		{FirstRoot(65000), RootSpacing(40003), CheckMiscorrection(true)},
+	} {
+		golden := makeWideData(1000)
+		goldenECC := make([]uint16, 20)
+		NewWideEncoder(Field65536(), len(goldenECC), opts...).Encode(golden, goldenECC)
+		d := NewWideDecoder(Field65536(), opts...)
+		for _, nbErasures := range []int{0, 6} {
+			nbErrors := (len(goldenECC) - nbErasures) / 2
+			data := append([]uint16{}, golden...)
+			ecc := append([]uint16{}, goldenECC...)
+			perm := rand.Perm(len(data) + len(ecc))
+			corruptWide(data, ecc, perm[:nbErasures+nbErrors])
+			n, m, err := d.DecodeErasures(data, ecc, perm[:nbErasures])
+			if err != nil || n != nbErrors || m != nbErasures {
+				t.Fatalf("expected %d errors, %d erasures; got %d, %d: %v", nbErrors, nbErasures, n, m, err)
+			}
+			if !reflect.DeepEqual(data, golden) || !reflect.DeepEqual(ecc, goldenECC) {
+				t.Fatal("not corrected")
+			}
+		}
+	}
+}
+
+func TestWideDecodeInvalidSymbol(t *testing.T) {
+	d := NewWideDecoder(NewWideField(0x13, 2))
+	if _, err := d.Decode([]uint16{1, 16}, make([]uint16, 2)); err == nil {
+		t.Fatal("expected failure")
+	}
+}
+
+func TestWideDecodeTooLong(t *testing.T) {
+	d := NewWideDecoder(Field65536())
+	if _, err := d.Decode(make([]uint16, 65535), make([]uint16, 1)); err == nil {
+		t.Fatal("expected failure")
+	}
+}
+
+// corruptWide flips bits at the specified positions, where data is indexed
+// first and ecc follows.
+func corruptWide(data, ecc []uint16, positions []int) {
+	for _, p := range positions {
+		v := uint16(1 + rand.Intn(65535))
+		if p < len(data) {
+			data[p] ^= v
+		} else {
+			ecc[p-len(data)] ^= v
+		}
+	}
+}
+
+func BenchmarkWideDecode1000_20With1Error(b *testing.B) {
+	b.StopTimer()
+	data := makeWideData(1000)
+	ecc := make([]uint16, 20)
+	NewWideEncoder(Field65536(), len(ecc)).Encode(data, ecc)
+	d := NewWideDecoder(Field65536())
+	data[1] ^= 1
+	b.SetBytes(int64(2 * len(data)))
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		if n, err := d.Decode(append([]uint16{}, data...), ecc); err != nil || n != 1 {
+			b.Fail()
+		}
+	}
+}
@@ -0,0 +1,66 @@
+/* Copyright 2012 Marc-Antoine Ruel. Licensed under the Apache License, Version
+2.0 (the "License"); you may not use this file except in compliance with the
+License.  You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0. Unless required by applicable law or
+agreed to in writing, software distributed under the License is distributed on
+an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+or implied. See the License for the specific language governing permissions and
+limitations under the License. */
+
+package rs
+
+// WideEncoder can encode data into ecc codes over a WideField or a
+// PrimeField.
+type WideEncoder interface {
+	// Encode calculates the ECC code for data and writes it into ecc.
+	Encode(data []uint16, ecc []uint16)
+}
+
+type wideEncoder struct {
+	f   *WideField
+	gen []uint16 // Generator polynomial, without its leading 1 term.
+}
+
+// NewWideEncoder generates a Reed-Solomon encoder that can generate c ECC
+// symbols over a WideField.
+//
+// The generator polynomial roots can be changed with FirstRoot and
+// RootSpacing.
+//
+// Encode panics if a data symbol is not valid in the field.
+func NewWideEncoder(f *WideField, c int, opts ...Option) WideEncoder {
+	o := getOptions(f.order(), opts)
+	// gen = Π(x + α^(prim*(fcr+i))) for i in [0, c).
+	gen := getWideOne(f)
+	for i := 0; i < c; i++ {
+		gen = gen.mulPoly(&widePoly{f, []uint16{1, f.exp(mulmod(o.prim, o.fcr+i, f.order()))}})
+	}
+	return &wideEncoder{f, gen.coefficients[1:]}
+}
+
+func (w *wideEncoder) Encode(data []uint16, ecc []uint16) {
+	if len(ecc) != len(w.gen) {
+		panic("rs: invalid ECC length")
+	}
+	if len(ecc) == 0 {
+		return
+	}
+	for i := range ecc {
+		ecc[i] = 0
+	}
+	// The ECC symbols are the remainder of data·x^c divided by the generator,
+	// calculated with a linear feedback shift register.
+	for _, d := range data {
+		if int(d) >= w.f.Size() {
+			panic("rs: invalid symbol for the field")
+		}
+		feedback := w.f.add(d, ecc[0])
+		copy(ecc, ecc[1:])
+		ecc[len(ecc)-1] = 0
+		if feedback != 0 {
+			for j, g := range w.gen {
+				ecc[j] = w.f.add(ecc[j], w.f.mul(feedback, g))
+			}
+		}
+	}
+}
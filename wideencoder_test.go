@@ -0,0 +1,143 @@
+/* Copyright 2012 Marc-Antoine Ruel. Licensed under the Apache License, Version
+2.0 (the "License"); you may not use this file except in compliance with the
+License.  You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0. Unless required by applicable law or
+agreed to in writing, software distributed under the License is distributed on
+an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+or implied. See the License for the specific language governing permissions and
+limitations under the License. */
+
+package rs
+
+import (
+	"testing"
+)
+
+func TestWideField(t *testing.T) {
+	f := Field65536()
+	if f.order() != 65535 {
+		t.Fatal(f.order())
+	}
+	if Field65536() != f {
+		t.Fatal("the field must be calculated once")
+	}
+	for _, x := range []uint16{1, 2, 3, 0x1234, 0xFFFF} {
+		if f.mul(x, f.inv(x)) != 1 {
+			t.Fatalf("%d * inv(%d) != 1", x, x)
+		}
+		if f.exp(f.log(x)) != x {
+			t.Fatalf("exp(log(%d)) != %d", x, x)
+		}
+		if f.mul(x, 3) != uint16(wideMul(int(x), 3, 0x1100B, 1<<16)) {
+			t.Fatalf("mul(%d, 3)", x)
+		}
+	}
+}
+
+func TestNewWideField(t *testing.T) {
+	data := []struct {
+		poly int
+		bits int
+	}{
+		{0xB, 3},
+		{0x13, 4},
+		{0x43, 6},
+		{0x11D, 8},
+		{0x409, 10},
+		{0x1069, 12},
+		{0x1100B, 16},
+	}
+	for _, line := range data {
+		f := NewWideField(line.poly, 2)
+		if f.Bits() != line.bits || f.Size() != 1<<uint(line.bits) {
+			t.Fatalf("%#x: got %d bits, size %d", line.poly, f.Bits(), f.Size())
+		}
+	}
+}
+
+func TestNewWideFieldInvalid(t *testing.T) {
+	data := []struct {
+		poly int
+		α    uint16
+	}{
+		// Degree too low.
+		{0x7, 2},
+		// Degree too high.
+		{0x20003, 2},
+		// x^16 is reducible.
+		{0x10000, 2},
+		// 1 doesn't generate the field.
+		{0x13, 1},
+		// α is not in GF(16).
+		{0x13, 16},
+	}
+	for _, line := range data {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("%#x, %d: expected panic", line.poly, line.α)
+				}
+			}()
+			NewWideField(line.poly, line.α)
+		}()
+	}
+}
+
+// GF(256) as a WideField must give the same result as Field.
+func TestWideEncodeISO18004Example(t *testing.T) {
+	f := NewWideField(0x11D, 2)
+	data := make([]uint16, len(QRCodeTestData))
+	for i, v := range QRCodeTestData {
+		data[i] = uint16(v)
+	}
+	ecc := make([]uint16, len(QRCodeTestECC))
+	NewWideEncoder(f, len(ecc)).Encode(data, ecc)
+	for i, v := range QRCodeTestECC {
+		if ecc[i] != uint16(v) {
+			t.Fatalf("ECC differs: %v != %v", ecc, QRCodeTestECC)
+		}
+	}
+}
+
+func TestWideEncodeInvalidSymbol(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic")
+		}
+	}()
+	NewWideEncoder(NewWideField(0x13, 2), 2).Encode([]uint16{16}, make([]uint16, 2))
+}
+
+func TestWideEncode(t *testing.T) {
+	f := Field65536()
+	data := makeWideData(1000)
+	ecc := make([]uint16, 20)
+	NewWideEncoder(f, len(ecc)).Encode(data, ecc)
+	// A valid codeword evaluates to zero at every root of the generator.
+	codeword := &widePoly{f, append(append([]uint16{}, data...), ecc...)}
+	for i := 0; i < len(ecc); i++ {
+		if v := codeword.evaluateAt(f.exp(i)); v != 0 {
+			t.Fatalf("codeword(α^%d) = %d", i, v)
+		}
+	}
+}
+
+func makeWideData(n int) []uint16 {
+	data := make([]uint16, n)
+	for i := range data {
+		data[i] = uint16(Rand128[i%len(Rand128)])<<8 | uint16(Rand128[(i+1)%len(Rand128)]) + uint16(i)
+	}
+	return data
+}
+
+func BenchmarkWideEncode1000_20(b *testing.B) {
+	b.StopTimer()
+	data := makeWideData(1000)
+	ecc := make([]uint16, 20)
+	e := NewWideEncoder(Field65536(), len(ecc))
+	b.SetBytes(int64(2 * len(data)))
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		e.Encode(data, ecc)
+	}
+}
@@ -0,0 +1,141 @@
+/* Copyright 2012 Marc-Antoine Ruel. Licensed under the Apache License, Version
+2.0 (the "License"); you may not use this file except in compliance with the
+License.  You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0. Unless required by applicable law or
+agreed to in writing, software distributed under the License is distributed on
+an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+or implied. See the License for the specific language governing permissions and
+limitations under the License. */
+
+package rs
+
+import (
+	"strconv"
+	"sync"
+)
+
+// Field65536 returns GF(2^16), which permits codewords of up to 65535 symbols.
+//
+// Its tables take 384KiB so they are only calculated on the first call.
+//
+// x^16 + x^12 + x^3 + x + 1
+func Field65536() *WideField {
+	field65536Once.Do(func() {
+		field65536 = NewWideField(0x1100B, 2)
+	})
+	return field65536
+}
+
+var (
+	field65536Once sync.Once
+	field65536     *WideField
+)
+
+// WideField is a Galois field GF(2^m), with m between 3 and 16, where each
+// symbol is stored in an uint16.
+//
+// Contrary to Field, it is not limited to 255 symbols per codeword; a codeword
+// can contain up to 2^m-1 symbols.
+type WideField struct {
+	bits int
+	exps []uint16 // α^i, repeated twice so the sum of two logs can be looked up.
+	logs []uint16 // logs[0] is unused.
+}
+
+// NewWideField returns the field GF(2^m) defined by the primitive polynomial
+// poly and generator α. The symbol width m is the degree of poly, e.g. 0x13
+// (x^4 + x + 1) defines GF(16) and 0x1100B defines GF(65536).
+//
+// It panics if the degree of poly is not between 3 and 16 or if α does not
+// generate the whole multiplicative group.
+func NewWideField(poly int, α uint16) *WideField {
+	bits := 0
+	for p := poly; p > 1; p >>= 1 {
+		bits++
+	}
+	if bits < 3 || bits > 16 {
+		panic("rs: invalid polynomial: " + strconv.Itoa(poly))
+	}
+	size := 1 << uint(bits)
+	if int(α) >= size {
+		panic("rs: invalid generator " + strconv.Itoa(int(α)) + " for polynomial " + strconv.Itoa(poly))
+	}
+	f := &WideField{bits: bits, exps: make([]uint16, 2*(size-1)), logs: make([]uint16, size)}
+	x := 1
+	for i := 0; i < size-1; i++ {
+		if x == 1 && i != 0 {
+			panic("rs: invalid generator " + strconv.Itoa(int(α)) + " for polynomial " + strconv.Itoa(poly))
+		}
+		f.exps[i] = uint16(x)
+		f.exps[i+size-1] = uint16(x)
+		f.logs[x] = uint16(i)
+		x = wideMul(x, int(α), poly, size)
+	}
+	if x != 1 {
+		panic("rs: invalid polynomial: " + strconv.Itoa(poly))
+	}
+	return f
+}
+
+// Bits returns the number of bits per symbol, m in GF(2^m).
+func (f *WideField) Bits() int {
+	return f.bits
+}
+
+// Size returns the number of elements in the field, 2^m. Valid symbols are in
+// the range [0, Size()).
+func (f *WideField) Size() int {
+	return len(f.logs)
+}
+
+// order returns the number of non-zero elements in the field.
+func (f *WideField) order() int {
+	return len(f.logs) - 1
+}
+
+func (f *WideField) add(x, y uint16) uint16 {
+	return x ^ y
+}
+
+// exp returns α^e. e must be positive.
+func (f *WideField) exp(e int) uint16 {
+	return f.exps[e%f.order()]
+}
+
+// log returns the base-α logarithm of x. It returns -1 if x == 0.
+func (f *WideField) log(x uint16) int {
+	if x == 0 {
+		return -1
+	}
+	return int(f.logs[x])
+}
+
+func (f *WideField) inv(x uint16) uint16 {
+	if x == 0 {
+		return 0
+	}
+	return f.exps[f.order()-int(f.logs[x])]
+}
+
+func (f *WideField) mul(x, y uint16) uint16 {
+	if x == 0 || y == 0 {
+		return 0
+	}
+	return f.exps[int(f.logs[x])+int(f.logs[y])]
+}
+
+// wideMul multiplies x and y modulo poly without using lookup tables.
+func wideMul(x, y, poly, size int) int {
+	z := 0
+	for x > 0 {
+		if x&1 != 0 {
+			z ^= y
+		}
+		x >>= 1
+		y <<= 1
+		if y&size != 0 {
+			y ^= poly
+		}
+	}
+	return z
+}
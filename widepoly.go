@@ -0,0 +1,163 @@
+/* Copyright 2012 Marc-Antoine Ruel. Licensed under the Apache License, Version
+2.0 (the "License"); you may not use this file except in compliance with the
+License.  You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0. Unless required by applicable law or
+agreed to in writing, software distributed under the License is distributed on
+an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+or implied. See the License for the specific language governing permissions and
+limitations under the License. */
+
+package rs
+
+import (
+	"fmt"
+)
+
+// widePoly is the equivalent of Poly over a WideField.
+type widePoly struct {
+	field        *WideField
+	coefficients []uint16 // In reverse order.
+}
+
+// |coefficients| representing elements of the field, arranged from most
+// significant (highest-power term) coefficient to least significant.
+func makeWidePoly(field *WideField, coefficients []uint16) *widePoly {
+	if len(coefficients) == 0 {
+		return nil
+	}
+	// Leading term must be non-zero for anything except the constant polynomial "0".
+	firstNonZero := 0
+	for firstNonZero < len(coefficients)-1 && coefficients[firstNonZero] == 0 {
+		firstNonZero++
+	}
+	return &widePoly{field, coefficients[firstNonZero:]}
+}
+
+func getWideZero(field *WideField) *widePoly {
+	return &widePoly{field, []uint16{0}}
+}
+
+func getWideOne(field *WideField) *widePoly {
+	return &widePoly{field, []uint16{1}}
+}
+
+func (p *widePoly) degree() int {
+	return len(p.coefficients) - 1
+}
+
+// Returns the monomial representing coefficient * x^degree.
+func buildWideMonomial(field *WideField, degree int, coefficient uint16) *widePoly {
+	if degree < 0 {
+		return nil
+	}
+	if coefficient == 0 {
+		return getWideZero(field)
+	}
+	coefficients := make([]uint16, degree+1)
+	coefficients[0] = coefficient
+	return &widePoly{field, coefficients}
+}
+
+// Returns true iff this polynomial is the monomial "0".
+func (p *widePoly) isZero() bool {
+	return p.coefficients[0] == 0
+}
+
+// Returns coefficient of x^degree term in this polynomial.
+func (p *widePoly) getCoefficient(degree int) uint16 {
+	return p.coefficients[len(p.coefficients)-1-degree]
+}
+
+// Returns evaluation of this polynomial at a given point.
+func (p *widePoly) evaluateAt(a uint16) uint16 {
+	if a == 0 {
+		// Just return the x^0 coefficient
+		return p.getCoefficient(0)
+	}
+	result := p.coefficients[0]
+	for i := 1; i < len(p.coefficients); i++ {
+		result = p.field.add(p.field.mul(a, result), p.coefficients[i])
+	}
+	return result
+}
+
+func (p *widePoly) add(other *widePoly) *widePoly {
+	if p.isZero() {
+		return other
+	}
+	if other.isZero() {
+		return p
+	}
+	smaller := p.coefficients
+	larger := other.coefficients
+	if len(smaller) > len(larger) {
+		smaller, larger = larger, smaller
+	}
+	sumDiff := make([]uint16, len(larger))
+	lengthDiff := len(larger) - len(smaller)
+	// Copy high-order terms only found in higher-degree polynomial's coefficients
+	copy(sumDiff, larger[:lengthDiff])
+
+	for i := lengthDiff; i < len(larger); i++ {
+		sumDiff[i] = p.field.add(smaller[i-lengthDiff], larger[i])
+	}
+	return makeWidePoly(p.field, sumDiff)
+}
+
+func (p *widePoly) mulPoly(other *widePoly) *widePoly {
+	if p.isZero() || other.isZero() {
+		return getWideZero(p.field)
+	}
+	aCoefficients := p.coefficients
+	bCoefficients := other.coefficients
+	product := make([]uint16, len(aCoefficients)+len(bCoefficients)-1)
+	for i := 0; i < len(aCoefficients); i++ {
+		aCoeff := aCoefficients[i]
+		for j := 0; j < len(bCoefficients); j++ {
+			product[i+j] = p.field.add(product[i+j], p.field.mul(aCoeff, bCoefficients[j]))
+		}
+	}
+	return makeWidePoly(p.field, product)
+}
+
+func (p *widePoly) mulScalar(scalar uint16) *widePoly {
+	if scalar == 0 {
+		return getWideZero(p.field)
+	}
+	if scalar == 1 {
+		return p
+	}
+	product := make([]uint16, len(p.coefficients))
+	for i := 0; i < len(p.coefficients); i++ {
+		product[i] = p.field.mul(p.coefficients[i], scalar)
+	}
+	return makeWidePoly(p.field, product)
+}
+
+func (p *widePoly) mulByMonomial(degree int, coefficient uint16) *widePoly {
+	if degree < 0 {
+		return nil
+	}
+	if coefficient == 0 {
+		return getWideZero(p.field)
+	}
+	size := len(p.coefficients)
+	product := make([]uint16, size+degree)
+	for i := 0; i < size; i++ {
+		product[i] = p.field.mul(p.coefficients[i], coefficient)
+	}
+	return makeWidePoly(p.field, product)
+}
+
+// Returns the remainder of the division by x^degree, e.g. only the terms of
+// degree lower than degree.
+func (p *widePoly) modMonomial(degree int) *widePoly {
+	if len(p.coefficients) <= degree {
+		return p
+	}
+	return makeWidePoly(p.field, p.coefficients[len(p.coefficients)-degree:])
+}
+
+func (p *widePoly) String() string {
+	return fmt.Sprintf("widePoly{%v}", p.coefficients)
+}
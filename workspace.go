@@ -0,0 +1,209 @@
+/* Copyright 2012 Marc-Antoine Ruel. Licensed under the Apache License, Version
+2.0 (the "License"); you may not use this file except in compliance with the
+License.  You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0. Unless required by applicable law or
+agreed to in writing, software distributed under the License is distributed on
+an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+or implied. See the License for the specific language governing permissions and
+limitations under the License. */
+
+package rs
+
+import (
+	"fmt"
+)
+
+// Workspace holds the scratch buffers used by Decoder.DecodeWith so that
+// decoding doesn't allocate memory.
+//
+// The zero value is ready to use; the buffers grow on first use to fit the
+// largest number of ECC bytes seen. A Workspace must not be used concurrently.
+type Workspace struct {
+	syndromes  []byte
+	lambda     []byte
+	b          []byte
+	t          []byte
+	omega      []byte
+	locations  []byte
+	magnitudes []byte
+	positions  []int
+}
+
+// reset sizes the buffers for nbECC ECC bytes and clears them.
+func (w *Workspace) reset(nbECC int) {
+	if cap(w.lambda) < nbECC+1 {
+		w.syndromes = make([]byte, nbECC)
+		w.lambda = make([]byte, nbECC+1)
+		w.b = make([]byte, nbECC+1)
+		w.t = make([]byte, nbECC+1)
+		w.omega = make([]byte, nbECC)
+		w.locations = make([]byte, nbECC)
+		w.magnitudes = make([]byte, nbECC)
+		w.positions = make([]int, nbECC)
+	}
+	w.syndromes = w.syndromes[:nbECC]
+	w.lambda = w.lambda[:nbECC+1]
+	w.b = w.b[:nbECC+1]
+	w.t = w.t[:nbECC+1]
+	w.omega = w.omega[:nbECC]
+	for i := range w.lambda {
+		w.lambda[i] = 0
+	}
+}
+
+func (d *rSDecoder) DecodeWith(w *Workspace, data, ecc []byte, erasures []int) (int, int, error) {
+	if err := checkCodewordLen(data, ecc); err != nil {
+		return 0, 0, err
+	}
+	n := len(data) + len(ecc)
+	R := len(ecc)
+	if len(erasures) > R {
+		return 0, 0, fmt.Errorf("%w: too many erasures: %d > %d", ErrInvalidErasure, len(erasures), R)
+	}
+	for i, position := range erasures {
+		if position < 0 || position >= n {
+			return 0, 0, fmt.Errorf("%w: position %d out of range [0, %d)", ErrInvalidErasure, position, n)
+		}
+		for j := 0; j < i; j++ {
+			if erasures[j] == position {
+				return 0, 0, fmt.Errorf("%w: duplicate position %d", ErrInvalidErasure, position)
+			}
+		}
+	}
+	w.reset(R)
+	if !d.syndromesInPlace(data, ecc, w.syndromes) {
+		// Congrats! All the data was perfect.
+		return 0, 0, nil
+	}
+	// The erasure locator Γ(x) = Π(1 + Y_i·x), stored from the constant term up.
+	w.lambda[0] = 1
+	for i, position := range erasures {
+		y := d.location(n - 1 - position)
+		for j := i + 1; j > 0; j-- {
+			w.lambda[j] ^= d.f.f.Mul(y, w.lambda[j-1])
+		}
+	}
+	l := berlekampMassey(d.f, w.syndromes, w.lambda, w.b, w.t, len(erasures))
+	if w.lambda[l] == 0 {
+		return 0, 0, fmt.Errorf("%w: errata locator degree does not match its length %d", ErrKeyEquation, l)
+	}
+	for _, v := range w.lambda[l+1:] {
+		if v != 0 {
+			return 0, 0, fmt.Errorf("%w: errata locator degree exceeds its length %d", ErrKeyEquation, l)
+		}
+	}
+	nbErrors := l - len(erasures)
+	if 2*nbErrors+len(erasures) > R {
+		return 0, 0, fmt.Errorf("%w: %d errors and %d erasures with %d ECC bytes", ErrTooManyErrors, nbErrors, len(erasures), R)
+	}
+	psi := w.lambda[:l+1]
+	// Chien's search, limited to the positions in the codeword.
+	w.positions = w.positions[:0]
+	w.locations = w.locations[:0]
+	for position := 0; position < n && len(w.positions) < l; position++ {
+		x := d.location(n - 1 - position)
+		if evaluateLowFirst(d.f, psi, d.f.f.Inv(x)) == 0 {
+			w.positions = append(w.positions, position)
+			w.locations = append(w.locations, x)
+		}
+	}
+	if len(w.positions) != l {
+		// The missing roots are outside of the shortened codeword.
+		return 0, 0, fmt.Errorf("%w: error locator has fewer roots in the codeword than its degree %d", ErrMiscorrection, l)
+	}
+	if l == 0 {
+		return 0, 0, fmt.Errorf("%w: no error found while the syndrome is not zero", ErrBadLocation)
+	}
+	// The errata evaluator Ω(x) = S(x)·Ψ(x) mod x^R.
+	for k := range w.omega {
+		v := byte(0)
+		for j := 0; j <= k && j <= l; j++ {
+			v ^= d.f.f.Mul(psi[j], w.syndromes[k-j])
+		}
+		w.omega[k] = v
+	}
+	// Forney's formula: e = X^(1-fcr)·Ω(X^-1)/Ψ'(X^-1).
+	w.magnitudes = w.magnitudes[:0]
+	for _, x := range w.locations {
+		xInv := d.f.f.Inv(x)
+		// The formal derivative only keeps the odd powers in characteristic 2.
+		denominator := byte(0)
+		xInv2 := d.f.f.Mul(xInv, xInv)
+		for j := l - 1 + l&1; j > 0; j -= 2 {
+			denominator = d.f.f.Mul(denominator, xInv2) ^ psi[j]
+		}
+		if denominator == 0 {
+			return 0, 0, fmt.Errorf("%w: errata locator has a repeated root", ErrBadLocation)
+		}
+		e := d.f.f.Mul(evaluateLowFirst(d.f, w.omega, xInv), d.f.f.Inv(denominator))
+		w.magnitudes = append(w.magnitudes, d.f.f.Mul(e, d.f.Pow(x, 1-d.o.fcr)))
+	}
+	w.apply(data, ecc)
+	if d.o.recheck && d.syndromesInPlace(data, ecc, w.syndromes) {
+		// Revert the correction.
+		w.apply(data, ecc)
+		return 0, 0, ErrMiscorrection
+	}
+	nbErasures := 0
+	for i, position := range w.positions {
+		m := w.magnitudes[i]
+		if m != 0 {
+			for _, e := range erasures {
+				if e == position {
+					nbErasures++
+					break
+				}
+			}
+		}
+	}
+	return nbErrors, nbErasures, nil
+}
+
+// apply XORs the magnitudes at their positions. Applying twice reverts it.
+func (w *Workspace) apply(data, ecc []byte) {
+	for i, position := range w.positions {
+		if position < len(data) {
+			data[position] ^= w.magnitudes[i]
+		} else {
+			ecc[position-len(data)] ^= w.magnitudes[i]
+		}
+	}
+}
+
+// syndromesInPlace calculates the syndromes of data followed by ecc into s,
+// S_i being in s[i].
+//
+// Returns false if all the syndromes are zero, e.g. there is no error.
+func (d *rSDecoder) syndromesInPlace(data, ecc, s []byte) bool {
+	found := false
+	for i := range s {
+		s[i] = d.syndromeAt(data, ecc, i)
+		if s[i] != 0 {
+			found = true
+		}
+	}
+	return found
+}
+
+// syndromeAt returns the syndrome S_i of data followed by ecc.
+func (d *rSDecoder) syndromeAt(data, ecc []byte, i int) byte {
+	x := d.location(d.o.fcr + i)
+	v := byte(0)
+	for _, c := range data {
+		v = d.f.f.Mul(v, x) ^ c
+	}
+	for _, c := range ecc {
+		v = d.f.f.Mul(v, x) ^ c
+	}
+	return v
+}
+
+// evaluateLowFirst evaluates the polynomial with coefficients stored from the
+// constant term up at x.
+func evaluateLowFirst(f *Field, coefficients []byte, x byte) byte {
+	v := byte(0)
+	for i := len(coefficients) - 1; i >= 0; i-- {
+		v = f.f.Mul(v, x) ^ coefficients[i]
+	}
+	return v
+}
@@ -0,0 +1,154 @@
+/* Copyright 2012 Marc-Antoine Ruel. Licensed under the Apache License, Version
+2.0 (the "License"); you may not use this file except in compliance with the
+License.  You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0. Unless required by applicable law or
+agreed to in writing, software distributed under the License is distributed on
+an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+or implied. See the License for the specific language governing permissions and
+limitations under the License. */
+
+package rs
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestDecodeWith(t *testing.T) {
+	w := &Workspace{}
+	data := []struct{ fcr, prim int }{{0, 1}, {1, 1}, {112, 11}}
+	for _, line := range data {
+		opts := []Option{FirstRoot(line.fcr), RootSpacing(line.prim)}
+		golden := makecopy(Rand128[:100])
+		goldenECC := make([]byte, 16)
+		NewEncoder(QRCodeField256, len(goldenECC), opts...).Encode(golden, goldenECC)
+		d := NewDecoder(QRCodeField256, opts...)
+		for nbErasures := 0; nbErasures <= len(goldenECC); nbErasures++ {
+			nbErrors := (len(goldenECC) - nbErasures) / 2
+			complete := append(makecopy(golden), goldenECC...)
+			perm := rand.Perm(len(complete))
+			for _, p := range perm[:nbErasures+nbErrors] {
+				complete[p] ^= byte(1 + rand.Intn(255))
+			}
+			data, ecc := complete[:len(golden)], complete[len(golden):]
+			n, m, err := d.DecodeWith(w, data, ecc, perm[:nbErasures])
+			if err != nil || n != nbErrors || m != nbErasures {
+				t.Fatalf("fcr=%d, prim=%d: expected %d errors, %d erasures; got %d, %d: %v", line.fcr, line.prim, nbErrors, nbErasures, n, m, err)
+			}
+			compare(t, data, golden, "Data differs")
+			compare(t, ecc, goldenECC, "ECC differs")
+		}
+	}
+}
+
+func TestDecodeWithQR(t *testing.T) {
+	w := &Workspace{}
+	d := NewDecoder(QRCodeField256)
+	if n, m, err := d.DecodeWith(w, makecopy(QRCodeTestData), makecopy(QRCodeTestECC), nil); n != 0 || m != 0 || err != nil {
+		t.Fatal(n, m, err)
+	}
+	// Same results as DecodeErasures, even when decoding fails.
+	for i := 0; i < 1000; i++ {
+		complete := getcomplete()
+		corrupt(complete, 1+rand.Intn(8))
+		erasures := rand.Perm(len(complete))[:rand.Intn(4)]
+		c := makecopy(complete)
+		n1, m1, err1 := d.DecodeErasures(complete[:len(QRCodeTestData)], complete[len(QRCodeTestData):], erasures)
+		n2, m2, err2 := d.DecodeWith(w, c[:len(QRCodeTestData)], c[len(QRCodeTestData):], erasures)
+		if (err1 == nil) != (err2 == nil) {
+			t.Fatalf("%v != %v", err1, err2)
+		}
+		if err1 == nil {
+			if n1 != n2 || m1 != m2 {
+				t.Fatalf("%d, %d != %d, %d", n1, m1, n2, m2)
+			}
+			compare(t, c, complete, "Result differs")
+		}
+	}
+}
+
+func TestDecodeWithInvalid(t *testing.T) {
+	w := &Workspace{}
+	d := NewDecoder(QRCodeField256)
+	for _, erasures := range [][]int{{-1}, {26}, {1, 1}, {0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10}} {
+		if _, _, err := d.DecodeWith(w, makecopy(QRCodeTestData), makecopy(QRCodeTestECC), erasures); err == nil {
+			t.Fatalf("%v: expected error", erasures)
+		}
+	}
+}
+
+func TestDecodeWithAllocs(t *testing.T) {
+	w := &Workspace{}
+	d := NewDecoder(QRCodeField256)
+	complete := getcomplete()
+	corrupt(complete, 3)
+	c := makecopy(complete)
+	erasures := []int{0}
+	allocs := testing.AllocsPerRun(100, func() {
+		copy(c, complete)
+		if _, _, err := d.DecodeWith(w, c[:len(QRCodeTestData)], c[len(QRCodeTestData):], erasures); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if allocs != 0 {
+		t.Fatalf("%g allocations", allocs)
+	}
+}
+
+func BenchmarkDecodeWith16_10(b *testing.B) {
+	b.StopTimer()
+	w := &Workspace{}
+	d := NewDecoder(QRCodeField256)
+	data := makecopy(QRCodeTestData)
+	ecc := makecopy(QRCodeTestECC)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(data)))
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		if n, _, err := d.DecodeWith(w, data, ecc, nil); err != nil || n != 0 {
+			b.Fail()
+		}
+	}
+}
+
+func BenchmarkDecodeWith16_10With1Error(b *testing.B) {
+	b.StopTimer()
+	w := &Workspace{}
+	d := NewDecoder(QRCodeField256)
+	golden := makecopy(QRCodeTestData)
+	golden[1] = golden[1] + 1
+	data := makecopy(golden)
+	ecc := makecopy(QRCodeTestECC)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(data)))
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		copy(data, golden)
+		if n, _, err := d.DecodeWith(w, data, ecc, nil); err != nil || n != 1 {
+			b.Fail()
+		}
+	}
+}
+
+func BenchmarkDecodeWith128_16With2Error(b *testing.B) {
+	b.StopTimer()
+	w := &Workspace{}
+	golden := makecopy(Rand128)
+	goldenECC := make([]byte, 16)
+	NewEncoder(QRCodeField256, len(goldenECC)).Encode(golden, goldenECC)
+	d := NewDecoder(QRCodeField256)
+	golden[1] = golden[1] + 1
+	goldenECC[1] = goldenECC[1] + 1
+	data := makecopy(golden)
+	ecc := makecopy(goldenECC)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(data)))
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		copy(data, golden)
+		copy(ecc, goldenECC)
+		if n, _, err := d.DecodeWith(w, data, ecc, nil); err != nil || n != 2 {
+			b.Fail()
+		}
+	}
+}